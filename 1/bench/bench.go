@@ -0,0 +1,190 @@
+// Package bench times the sorting algorithms over inputs of different
+// sizes and shapes and reports how long they took and how much work they did.
+package bench
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+
+	"example.com/m/v2/datagen"
+	"example.com/m/v2/sorting"
+	"example.com/m/v2/stats"
+)
+
+// Algorithm is a sort the harness can run.
+type Algorithm struct {
+	// Name identifies the algorithm in reports.
+	Name string
+	// Quadratic is set for sorts that take O(n²) time on some inputs, so
+	// they can be skipped for large sizes.
+	Quadratic bool
+	// Sort sorts arr in place, recording its work in s if s is not nil.
+	Sort func(arr []int, s *stats.Stats)
+}
+
+// The registered sorts that take O(n²) time on some inputs.
+var quadratic = map[string]bool{
+	"binary_insertion_sort":  true,
+	"bubble_sort":            true,
+	"cocktail_shaker_sort":   true,
+	"comb_sort":              true,
+	"gnome_sort":             true,
+	"insertion_sort":         true,
+	"odd_even_sort":          true,
+	"parallel_odd_even_sort": true,
+	"quicksort_lomuto":       true,
+}
+
+// Algorithms returns every registered comparison sort plus the integer sorts.
+func Algorithms() []Algorithm {
+	var algorithms []Algorithm
+	for _, name := range sorting.Names() {
+		sorter, _ := sorting.ByName[int](name)
+		algorithms = append(algorithms, Algorithm{
+			Name:      name,
+			Quadratic: quadratic[name],
+			Sort: func(arr []int, s *stats.Stats) {
+				sorter.SortStats(arr, sorting.Less[int], s)
+			},
+		})
+	}
+
+	algorithms = append(algorithms,
+		Algorithm{Name: "counting_sort", Sort: counting_sort},
+		Algorithm{Name: "radix_sort", Sort: func(arr []int, s *stats.Stats) { sorting.RadixSort(arr) }},
+	)
+	return algorithms
+}
+
+// AlgorithmByName returns the algorithm called name.
+func AlgorithmByName(name string) (Algorithm, error) {
+	for _, a := range Algorithms() {
+		if a.Name == name {
+			return a, nil
+		}
+	}
+	return Algorithm{}, fmt.Errorf("bench: unknown algorithm %q", name)
+}
+
+// counting_sort sorts arr with a counting sort over the range of its values.
+func counting_sort(arr []int, s *stats.Stats) {
+	if len(arr) == 0 {
+		return
+	}
+	min, max := arr[0], arr[0]
+	for _, v := range arr {
+		if v < min {
+			min = v
+		}
+		if v > max {
+			max = v
+		}
+	}
+	sorted := sorting.CountingSort(arr, min, max+1, func(v int) int { return v })
+	copy(arr, sorted)
+}
+
+// Config says which benchmarks to run.
+type Config struct {
+	Algorithms    []Algorithm
+	Distributions []datagen.Distribution
+	Sizes         []int
+	// Seed makes the inputs reproducible.
+	Seed int64
+	// Repeats is how many times each sort is timed. The fastest time is
+	// reported. If it is less than 1, each sort is timed once.
+	Repeats int
+	// QuadraticLimit is the largest size at which quadratic sorts are run.
+	// If it is not positive they are run at every size.
+	QuadraticLimit int
+}
+
+// Result is the outcome of running one algorithm on one input.
+type Result struct {
+	Distribution string `json:"distribution"`
+	// Nanoseconds is the fastest uninstrumented run.
+	Nanoseconds int64 `json:"nanoseconds"`
+	stats.Report
+}
+
+// Run runs every algorithm in config over every distribution and size.
+// Each sort is timed without instrumentation and then run once more to count its work.
+func Run(config Config) ([]Result, error) {
+	if config.Repeats < 1 {
+		config.Repeats = 1
+	}
+
+	var results []Result
+	for _, size := range config.Sizes {
+		for _, dist := range config.Distributions {
+			// Every algorithm sorts the same input.
+			input := dist.Make(datagen.New(config.Seed), size)
+
+			for _, alg := range config.Algorithms {
+				if alg.Quadratic && config.QuadraticLimit > 0 && size > config.QuadraticLimit {
+					continue
+				}
+
+				arr := make([]int, size)
+				var best time.Duration
+				for rep := 0; rep < config.Repeats; rep++ {
+					copy(arr, input)
+					start := time.Now()
+					alg.Sort(arr, nil)
+					elapsed := time.Since(start)
+					if rep == 0 || elapsed < best {
+						best = elapsed
+					}
+				}
+				if !sorting.IsSorted(arr) {
+					return nil, fmt.Errorf("bench: %s did not sort %d %s items", alg.Name, size, dist.Name)
+				}
+
+				var counts stats.Stats
+				copy(arr, input)
+				alg.Sort(arr, &counts)
+
+				results = append(results, Result{
+					Distribution: dist.Name,
+					Nanoseconds:  best.Nanoseconds(),
+					Report:       stats.MakeReport(alg.Name, size, &counts),
+				})
+			}
+		}
+	}
+	return results, nil
+}
+
+// WriteResults writes results to w as "text", "json" or "csv".
+func WriteResults(w io.Writer, format string, results []Result) error {
+	switch format {
+	case "text":
+		for _, r := range results {
+			_, err := fmt.Fprintf(w, "%-20s %-14s %9d items %14v %12d comparisons %12d swaps\n",
+				r.Algorithm, r.Distribution, r.Items, time.Duration(r.Nanoseconds), r.Comparisons, r.Swaps)
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	case "json":
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(results)
+	case "csv":
+		writer := csv.NewWriter(w)
+		header := append([]string{"distribution", "nanoseconds"}, stats.CSVHeader()...)
+		writer.Write(header)
+		for _, r := range results {
+			record := append([]string{r.Distribution, strconv.FormatInt(r.Nanoseconds, 10)}, r.CSVRecord()...)
+			writer.Write(record)
+		}
+		writer.Flush()
+		return writer.Error()
+	}
+	return fmt.Errorf("bench: unknown output format %q", format)
+}
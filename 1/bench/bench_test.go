@@ -0,0 +1,68 @@
+package bench
+
+import (
+	"fmt"
+	"testing"
+
+	"example.com/m/v2/datagen"
+	"example.com/m/v2/sorting"
+	"example.com/m/v2/stats"
+)
+
+// The sizes benchmarked, and the largest at which quadratic sorts are run.
+var (
+	benchmark_sizes           = []int{1000, 10000, 100000}
+	benchmark_quadratic_limit = 10000
+)
+
+// BenchmarkSort runs every algorithm on every distribution and size, as
+// sub-benchmarks named algorithm/distribution/n=size, so a subset can be
+// picked with -bench, e.g. -bench 'Sort/quicksort/sorted'. Besides the time
+// it reports the comparisons and swaps of one instrumented run.
+//
+// cmd/sort_bench runs the same grid once and writes it as a table.
+func BenchmarkSort(b *testing.B) {
+	for _, alg := range Algorithms() {
+		alg := alg
+		b.Run(alg.Name, func(b *testing.B) {
+			for _, dist := range datagen.Distributions {
+				dist := dist
+				b.Run(dist.Name, func(b *testing.B) {
+					for _, size := range benchmark_sizes {
+						if alg.Quadratic && size > benchmark_quadratic_limit {
+							continue
+						}
+						input := dist.Make(datagen.New(1), size)
+						b.Run(fmt.Sprintf("n=%d", size), func(b *testing.B) {
+							benchmark_sort(b, alg, input)
+						})
+					}
+				})
+			}
+		})
+	}
+}
+
+// benchmark_sort times alg sorting a fresh copy of input b.N times.
+func benchmark_sort(b *testing.B, alg Algorithm, input []int) {
+	arr := make([]int, len(input))
+	var counts stats.Stats
+	copy(arr, input)
+	alg.Sort(arr, &counts)
+	if !sorting.IsSorted(arr) {
+		b.Fatalf("%s did not sort %d items", alg.Name, len(input))
+	}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		b.StopTimer()
+		copy(arr, input)
+		b.StartTimer()
+		alg.Sort(arr, nil)
+	}
+	// The integer sorts don't compare or swap.
+	if counts.Comparisons > 0 {
+		b.ReportMetric(float64(counts.Comparisons), "comparisons/op")
+		b.ReportMetric(float64(counts.Swaps), "swaps/op")
+	}
+}
@@ -0,0 +1,303 @@
+// Package cli holds the plumbing shared by the demo programs of every chapter:
+// reading values from flags or newline-delimited stdin, prompting only when a
+// person is typing, writing plain text or JSON, and exiting with a useful status.
+package cli
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"example.com/m/v2/datagen"
+)
+
+// ErrInvalid marks errors caused by bad flags or input. Check exits with
+// status 2 for these and status 1 for everything else.
+var ErrInvalid = errors.New("invalid input")
+
+// Invalidf returns an error that wraps ErrInvalid.
+func Invalidf(format string, args ...any) error {
+	return fmt.Errorf("%w: %s", ErrInvalid, fmt.Sprintf(format, args...))
+}
+
+// Check does nothing if err is nil. Otherwise it prints err to stderr and exits.
+func Check(err error) {
+	if err == nil {
+		return
+	}
+	fmt.Fprintf(os.Stderr, "%s: %v\n", filepath.Base(os.Args[0]), err)
+	if errors.Is(err, ErrInvalid) {
+		os.Exit(2)
+	}
+	os.Exit(1)
+}
+
+// IsTerminal reports whether f is an interactive terminal rather than a pipe or file.
+func IsTerminal(f *os.File) bool {
+	info, err := f.Stat()
+	return err == nil && info.Mode()&os.ModeCharDevice != 0
+}
+
+// IsSet reports whether the flag called name was given on the command line.
+func IsSet(name string) bool {
+	set := false
+	flag.Visit(func(f *flag.Flag) {
+		if f.Name == name {
+			set = true
+		}
+	})
+	return set
+}
+
+// Generator returns a data generator seeded with seed if the -seed flag was
+// given and otherwise from the clock. Demos print g.Seed() so that a run can
+// be repeated.
+func Generator(seed int64) *datagen.Generator {
+	if !IsSet("seed") {
+		seed = datagen.TimeSeed()
+	}
+	return datagen.New(seed)
+}
+
+// Input reads newline-delimited values, prompting for each one only when
+// a person is typing them.
+type Input struct {
+	scanner     *bufio.Scanner
+	prompts     io.Writer
+	interactive bool
+	line        int
+}
+
+// NewInput reads values from r. If interactive is set, prompts are written to prompts.
+func NewInput(r io.Reader, prompts io.Writer, interactive bool) *Input {
+	return &Input{scanner: bufio.NewScanner(r), prompts: prompts, interactive: interactive}
+}
+
+// Stdin reads values from os.Stdin, prompting on os.Stdout if it is a terminal.
+func Stdin() *Input {
+	return NewInput(os.Stdin, os.Stdout, IsTerminal(os.Stdin))
+}
+
+// Interactive reports whether a person is typing the input.
+func (in *Input) Interactive() bool {
+	return in.interactive
+}
+
+// Line shows prompt if the input is interactive and returns the next line with
+// surrounding space removed. At the end of the input it returns io.EOF.
+func (in *Input) Line(prompt string) (string, error) {
+	if in.interactive {
+		fmt.Fprint(in.prompts, prompt)
+	}
+	if !in.scanner.Scan() {
+		if err := in.scanner.Err(); err != nil {
+			return "", err
+		}
+		return "", io.EOF
+	}
+	in.line++
+	return strings.TrimSpace(in.scanner.Text()), nil
+}
+
+// Int reads the next line as an integer.
+func (in *Input) Int(prompt string) (int, error) {
+	text, err := in.Line(prompt)
+	if err == io.EOF {
+		return 0, Invalidf("missing value for %s", strings.TrimSuffix(strings.TrimSpace(prompt), ":"))
+	}
+	if err != nil {
+		return 0, err
+	}
+	return in.parse_int(text)
+}
+
+// IntOrFlag returns the value of the flag called name if it was given and
+// otherwise reads it from the input.
+func (in *Input) IntOrFlag(name string, value int, prompt string) (int, error) {
+	if IsSet(name) {
+		return value, nil
+	}
+	return in.Int(prompt)
+}
+
+// EachInt calls f for every integer in list, a comma-separated flag value, if
+// the flag called name was given. Otherwise it calls f for every integer read
+// from the input until the input ends. When the input is interactive a blank
+// line also ends it; otherwise blank lines are skipped.
+func (in *Input) EachInt(name, list, prompt string, f func(v int) error) error {
+	from_flag := IsSet(name)
+	return in.EachString(name, list, prompt, func(text string) error {
+		v, err := in.parse_int(text)
+		if err != nil && from_flag {
+			err = Invalidf("-%s: %q is not an integer", name, text)
+		}
+		if err != nil {
+			return err
+		}
+		return f(v)
+	})
+}
+
+// EachString is like EachInt but passes f each value as a string, with
+// surrounding space removed.
+func (in *Input) EachString(name, list, prompt string, f func(v string) error) error {
+	if IsSet(name) {
+		for _, field := range strings.Split(list, ",") {
+			if err := f(strings.TrimSpace(field)); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	for {
+		text, err := in.Line(prompt)
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if text == "" {
+			if in.interactive {
+				return nil
+			}
+			continue
+		}
+
+		if err := f(text); err != nil {
+			return err
+		}
+	}
+}
+
+// EachGroup calls f with values in groups of len(prompts). If the flag called
+// name was given, the groups come from list, separated by commas, with the
+// values in each group separated by spaces. Otherwise the values are read from
+// the input one per line, and prompts[i] is shown before the i'th value of
+// each group.
+func (in *Input) EachGroup(name, list string, prompts []string, f func(values []string) error) error {
+	if IsSet(name) {
+		for _, group := range strings.Split(list, ",") {
+			values := strings.Fields(group)
+			if len(values) != len(prompts) {
+				return Invalidf("-%s: %q should hold %d values", name, strings.TrimSpace(group), len(prompts))
+			}
+			if err := f(values); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	var values []string
+	for {
+		text, err := in.Line(prompts[len(values)])
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return err
+		}
+		if text == "" {
+			if in.interactive {
+				return nil
+			}
+			continue
+		}
+
+		values = append(values, text)
+		if len(values) == len(prompts) {
+			if err := f(values); err != nil {
+				return err
+			}
+			values = nil
+		}
+	}
+	if len(values) > 0 {
+		return Invalidf("the input ended partway through a group of %d values", len(prompts))
+	}
+	return nil
+}
+
+func (in *Input) parse_int(text string) (int, error) {
+	v, err := strconv.Atoi(text)
+	if err != nil {
+		return 0, Invalidf("line %d: %q is not an integer", in.line, text)
+	}
+	return v, nil
+}
+
+// ValidateArray checks the size and maximum value requested for a random array.
+func ValidateArray(num_items, max int) error {
+	if num_items < 0 {
+		return Invalidf("the number of items must not be negative, got %d", num_items)
+	}
+	if max < 1 {
+		return Invalidf("the maximum value must be at least 1, got %d", max)
+	}
+	return nil
+}
+
+// Head returns the first n items of arr, or all of them if there are fewer.
+func Head[T any](arr []T, n int) []T {
+	if n < 0 {
+		n = 0
+	}
+	if n > len(arr) {
+		n = len(arr)
+	}
+	return arr[:n]
+}
+
+// Output writes results either as plain text or as one JSON object per line.
+type Output struct {
+	w    io.Writer
+	json bool
+}
+
+// NewOutput writes to w in the given format, "text" or "json".
+func NewOutput(w io.Writer, format string) (*Output, error) {
+	switch format {
+	case "text":
+		return &Output{w: w}, nil
+	case "json":
+		return &Output{w: w, json: true}, nil
+	}
+	return nil, Invalidf("unknown output format %q", format)
+}
+
+// JSON reports whether results are written as JSON.
+func (out *Output) JSON() bool {
+	return out.json
+}
+
+// Printf writes formatted text, but only in text mode.
+func (out *Output) Printf(format string, args ...any) {
+	if !out.json {
+		fmt.Fprintf(out.w, format, args...)
+	}
+}
+
+// Writer returns where text output goes, or io.Discard in JSON mode.
+func (out *Output) Writer() io.Writer {
+	if out.json {
+		return io.Discard
+	}
+	return out.w
+}
+
+// Record writes v as a single line of JSON, but only in JSON mode.
+func (out *Output) Record(v any) error {
+	if !out.json {
+		return nil
+	}
+	return json.NewEncoder(out.w).Encode(v)
+}
@@ -0,0 +1,93 @@
+package cli
+
+import (
+	"bytes"
+	"errors"
+	"flag"
+	"fmt"
+	"testing"
+
+	"example.com/m/v2/datagen"
+)
+
+func TestNewOutput(t *testing.T) {
+	type result struct {
+		N int `json:"n"`
+	}
+	for _, test := range []struct {
+		format string
+		json   bool
+		want   string
+	}{
+		{"text", false, "n = 1\nwritten\n"},
+		{"json", true, "{\"n\":1}\n"},
+	} {
+		var b bytes.Buffer
+		out, err := NewOutput(&b, test.format)
+		if err != nil {
+			t.Fatalf("NewOutput(%q): %v", test.format, err)
+		}
+		if out.JSON() != test.json {
+			t.Errorf("NewOutput(%q).JSON() = %v, want %v", test.format, out.JSON(), test.json)
+		}
+		out.Printf("n = %d\n", 1)
+		fmt.Fprintln(out.Writer(), "written")
+		if err := out.Record(result{N: 1}); err != nil {
+			t.Fatal(err)
+		}
+		if got := b.String(); got != test.want {
+			t.Errorf("%s output: got %q, want %q", test.format, got, test.want)
+		}
+	}
+
+	if _, err := NewOutput(&bytes.Buffer{}, "xml"); !errors.Is(err, ErrInvalid) {
+		t.Errorf("NewOutput with an unknown format returned %v, want an ErrInvalid", err)
+	}
+}
+
+// parse_command_line replaces the command line for the rest of the test with
+// one that has the flags made by define and parses args.
+func parse_command_line(t *testing.T, define func(), args ...string) {
+	t.Helper()
+	saved := flag.CommandLine
+	t.Cleanup(func() { flag.CommandLine = saved })
+	flag.CommandLine = flag.NewFlagSet("test", flag.ContinueOnError)
+	define()
+	if err := flag.CommandLine.Parse(args); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestIsSet(t *testing.T) {
+	parse_command_line(t, func() {
+		flag.Int("items", 10, "")
+		flag.Int("max", 10, "")
+	}, "-items", "20")
+
+	if !IsSet("items") {
+		t.Error("IsSet is false for a flag that was given")
+	}
+	if IsSet("max") {
+		t.Error("IsSet is true for a flag that wasn't given")
+	}
+	if IsSet("missing") {
+		t.Error("IsSet is true for a flag that doesn't exist")
+	}
+}
+
+func TestGenerator(t *testing.T) {
+	// Without a -seed flag the seed comes from the clock.
+	parse_command_line(t, func() { flag.Int64("seed", 0, "") })
+	if g := Generator(42); g.Seed() == 42 {
+		t.Errorf("Generator(42) used the seed although -seed wasn't given")
+	}
+
+	parse_command_line(t, func() { flag.Int64("seed", 0, "") }, "-seed", "42")
+	g, want := Generator(42), datagen.New(42)
+	if g.Seed() != 42 {
+		t.Fatalf("Generator(42).Seed() = %d with -seed given, want 42", g.Seed())
+	}
+	if a, b := g.Uniform(10, 1000), want.Uniform(10, 1000); fmt.Sprint(a) != fmt.Sprint(b) {
+		t.Errorf("Generator(42) made %v, want %v", a, b)
+	}
+}
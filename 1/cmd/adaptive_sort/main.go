@@ -0,0 +1,87 @@
+package main
+
+import (
+	"flag"
+	"os"
+	"strings"
+	"time"
+
+	"example.com/m/v2/cli"
+	"example.com/m/v2/datagen"
+	"example.com/m/v2/sorting"
+)
+
+// result is what the program reports for each distribution in JSON mode.
+type result struct {
+	Seed         int64                  `json:"seed"`
+	Distribution string                 `json:"distribution"`
+	Choice       sorting.AdaptiveChoice `json:"choice"`
+	Adaptive     int64                  `json:"adaptive_nanoseconds"`
+	Quicksort    int64                  `json:"quicksort_nanoseconds"`
+}
+
+func main() {
+	items_flag := flag.Int("items", 0, "number of items to sort (read from stdin if not given)")
+	distribution_names := flag.String("distributions", "all", "comma-separated input distributions, or all")
+	seed := flag.Int64("seed", 0, "random seed (default: the current time)")
+	format := flag.String("format", "text", "output format: text or json")
+	flag.Parse()
+
+	out, err := cli.NewOutput(os.Stdout, *format)
+	cli.Check(err)
+	g := cli.Generator(*seed)
+
+	var distributions []datagen.Distribution
+	if *distribution_names == "all" {
+		distributions = datagen.Distributions
+	} else {
+		for _, name := range strings.Split(*distribution_names, ",") {
+			dist, err := datagen.DistributionByName(strings.TrimSpace(name))
+			if err != nil {
+				cli.Check(cli.Invalidf("%v", err))
+			}
+			distributions = append(distributions, dist)
+		}
+	}
+
+	in := cli.Stdin()
+	num_items, err := in.IntOrFlag("items", *items_flag, "# Items: ")
+	cli.Check(err)
+	if num_items < 0 {
+		cli.Check(cli.Invalidf("the number of items must not be negative, got %d", num_items))
+	}
+
+	out.Printf("Seed: %d\n", g.Seed())
+	for _, dist := range distributions {
+		arr := dist.Make(g, num_items)
+
+		// Sort one copy adaptively and another with plain quicksort.
+		scratch := append([]int(nil), arr...)
+		start := time.Now()
+		choice := sorting.AdaptiveSort(scratch)
+		adaptive := time.Since(start)
+		if !sorting.IsSorted(scratch) {
+			cli.Check(cli.Invalidf("the array is NOT sorted"))
+		}
+
+		copy(scratch, arr)
+		start = time.Now()
+		sorting.Quicksort(scratch)
+		quicksort := time.Since(start)
+
+		p := choice.Presortedness
+		approx := ""
+		if p.Sampled {
+			approx = "~"
+		}
+		out.Printf("\n%s: %s (%s)\n", dist.Name, choice.Algorithm, choice.Reason)
+		out.Printf("    inversions %s%d, runs %d, longest increasing %s%d, max displacement %s%d\n",
+			approx, p.Inversions, p.Runs, approx, p.LongestIncreasing, approx, p.MaxDisplacement)
+		out.Printf("    adaptive %v, quicksort %v (%.2fx)\n", adaptive, quicksort, float64(quicksort)/float64(adaptive))
+
+		cli.Check(out.Record(result{
+			Seed: g.Seed(), Distribution: dist.Name, Choice: choice,
+			Adaptive: adaptive.Nanoseconds(), Quicksort: quicksort.Nanoseconds(),
+		}))
+	}
+}
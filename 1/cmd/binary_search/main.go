@@ -0,0 +1,93 @@
+package main
+
+import (
+	"flag"
+	"os"
+
+	"example.com/m/v2/cli"
+	"example.com/m/v2/search"
+	"example.com/m/v2/sorting"
+	"example.com/m/v2/stats"
+)
+
+// The searches to compare with binary search.
+var searches = []struct {
+	name   string
+	search func(arr []int, target int) (index, num_tests int)
+}{
+	{"Linear search", search.LinearSearch[int]},
+	{"Lower bound", search.LowerBound[int]},
+	{"Upper bound", search.UpperBound[int]},
+	{"First occurrence", search.FirstOccurrence[int]},
+	{"Last occurrence", search.LastOccurrence[int]},
+	{"Nearest", search.Nearest[int]},
+	{"Exponential search", search.ExponentialSearch[int]},
+	{"Interpolation search", search.InterpolationSearch[int]},
+}
+
+// search_result is one search's answer in JSON mode.
+type search_result struct {
+	Search   string `json:"search"`
+	Index    int    `json:"index"`
+	NumTests int    `json:"num_tests"`
+}
+
+// result is what the program reports for each target in JSON mode.
+type result struct {
+	Seed       int64           `json:"seed"`
+	Target     int             `json:"target"`
+	Index      int             `json:"index"`
+	NumTests   int             `json:"num_tests"`
+	EqualRange [2]int          `json:"equal_range"`
+	Others     []search_result `json:"others"`
+	Stats      stats.Report    `json:"stats"`
+}
+
+func main() {
+	items_flag := flag.Int("items", 0, "number of items to search (read from stdin if not given)")
+	max_flag := flag.Int("max", 0, "items are chosen from [0, max) (read from stdin if not given)")
+	targets := flag.String("targets", "", "comma-separated values to search for (read from stdin if not given)")
+	seed := flag.Int64("seed", 0, "random seed (default: the current time)")
+	show := flag.Int("show", 40, "number of items to display")
+	format := flag.String("format", "text", "output format: text or json")
+	flag.Parse()
+
+	out, err := cli.NewOutput(os.Stdout, *format)
+	cli.Check(err)
+	g := cli.Generator(*seed)
+
+	// Get the number of items and maximum item value.
+	in := cli.Stdin()
+	num_items, err := in.IntOrFlag("items", *items_flag, "# Items: ")
+	cli.Check(err)
+	max, err := in.IntOrFlag("max", *max_flag, "Max: ")
+	cli.Check(err)
+	cli.Check(cli.ValidateArray(num_items, max))
+
+	// Make, sort and display the array.
+	out.Printf("Seed: %d\n", g.Seed())
+	arr := g.Uniform(num_items, max)
+	sorting.Quicksort(arr)
+	sorting.PrintArray(out.Writer(), arr, *show)
+	out.Printf("\n")
+
+	err = in.EachInt("targets", *targets, "Target: ", func(target int) error {
+		s := &stats.Stats{}
+		index, num_tests := search.BinarySearchStats(arr, target, s)
+		out.Printf("Index: %d\nNum tests: %d\n", index, num_tests)
+		r := result{Seed: g.Seed(), Target: target, Index: index, NumTests: num_tests, Stats: stats.MakeReport("binary_search", len(arr), s)}
+
+		// Compare the other searches.
+		for _, other := range searches {
+			index, num_tests := other.search(arr, target)
+			out.Printf("    %-22s index %6d   %4d tests\n", other.name, index, num_tests)
+			r.Others = append(r.Others, search_result{Search: other.name, Index: index, NumTests: num_tests})
+		}
+		lo, hi, num_tests := search.EqualRange(arr, target)
+		out.Printf("    %-22s [%d, %d)   %4d tests\n", "Equal range", lo, hi, num_tests)
+		r.EqualRange = [2]int{lo, hi}
+
+		return out.Record(r)
+	})
+	cli.Check(err)
+}
@@ -0,0 +1,72 @@
+package main
+
+import (
+	"flag"
+	"os"
+
+	"example.com/m/v2/cli"
+	"example.com/m/v2/sorting"
+	"example.com/m/v2/stats"
+)
+
+// result is what the program reports in JSON mode.
+type result struct {
+	Seed      int64       `json:"seed"`
+	Algorithm string      `json:"algorithm"`
+	Items     int         `json:"items"`
+	Max       int         `json:"max"`
+	Head      []int       `json:"head"`
+	Sorted    bool        `json:"sorted"`
+	Stats     stats.Stats `json:"stats"`
+}
+
+func main() {
+	items_flag := flag.Int("items", 0, "number of items to sort (read from stdin if not given)")
+	max_flag := flag.Int("max", 0, "items are chosen from [0, max) (read from stdin if not given)")
+	seed := flag.Int64("seed", 0, "random seed (default: the current time)")
+	show := flag.Int("show", 40, "number of items to display")
+	format := flag.String("format", "text", "output format: text or json")
+	flag.Parse()
+
+	out, err := cli.NewOutput(os.Stdout, *format)
+	cli.Check(err)
+	g := cli.Generator(*seed)
+
+	// Get the number of items and maximum item value.
+	in := cli.Stdin()
+	num_items, err := in.IntOrFlag("items", *items_flag, "# Items: ")
+	cli.Check(err)
+	max, err := in.IntOrFlag("max", *max_flag, "Max: ")
+	cli.Check(err)
+	cli.Check(cli.ValidateArray(num_items, max))
+
+	// Make and display the unsorted array.
+	out.Printf("Seed: %d\n", g.Seed())
+	arr := g.Uniform(num_items, max)
+	sorting.PrintArray(out.Writer(), arr, *show)
+	out.Printf("\n")
+
+	// Sort and display the result, counting the work done.
+	var counts stats.Stats
+	sorter, _ := sorting.ByName[int]("bubble_sort")
+	sorter.SortStats(arr, sorting.Less[int], &counts)
+	sorting.PrintArray(out.Writer(), arr, *show)
+
+	// Verify that it's sorted.
+	sorted := sorting.IsSorted(arr)
+	sorting.CheckSorted(out.Writer(), arr)
+	out.Printf("%v\n", stats.MakeReport(sorter.Name(), len(arr), &counts))
+
+	cli.Check(out.Record(result{
+		Seed:      g.Seed(),
+		Algorithm: sorter.Name(),
+		Items:     num_items,
+		Max:       max,
+		Head:      cli.Head(arr, *show),
+		Sorted:    sorted,
+		Stats:     counts,
+	}))
+	if !sorted {
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,117 @@
+package main
+
+import (
+	"flag"
+	"math"
+	"os"
+	"strconv"
+
+	"example.com/m/v2/cli"
+	"example.com/m/v2/datagen"
+	"example.com/m/v2/sorting"
+	"example.com/m/v2/stats"
+)
+
+// The shapes of data the program can generate.
+var distributions = map[string]func(g *datagen.Generator, n int) []float64{
+	"uniform":     func(g *datagen.Generator, n int) []float64 { return g.UniformFloats(n, 0, 100) },
+	"normal":      func(g *datagen.Generator, n int) []float64 { return g.Normal(n, 50, 10) },
+	"exponential": exponential,
+	"special":     special,
+}
+
+// exponential returns n floats from an exponential distribution with mean 1.
+func exponential(g *datagen.Generator, n int) []float64 {
+	arr := make([]float64, n)
+	for i := range arr {
+		arr[i] = g.ExpFloat64()
+	}
+	return arr
+}
+
+// special returns n uniform floats with a sprinkling of NaNs and infinities.
+func special(g *datagen.Generator, n int) []float64 {
+	arr := g.UniformFloats(n, 0, 100)
+	for i := range arr {
+		switch g.Intn(10) {
+		case 0:
+			arr[i] = math.NaN()
+		case 1:
+			arr[i] = math.Inf(1 - 2*g.Intn(2))
+		}
+	}
+	return arr
+}
+
+// result is what the program reports in JSON mode.
+type result struct {
+	Seed         int64          `json:"seed"`
+	Items        int            `json:"items"`
+	Distribution string         `json:"distribution"`
+	Head         []string       `json:"head"`
+	Sorted       bool           `json:"sorted"`
+	Reports      []stats.Report `json:"reports"`
+}
+
+func main() {
+	items_flag := flag.Int("items", 0, "number of items to sort (read from stdin if not given)")
+	distribution := flag.String("distribution", "uniform", "shape of the data: uniform, normal, exponential or special")
+	seed := flag.Int64("seed", 0, "random seed (default: the current time)")
+	show := flag.Int("show", 10, "number of items to display")
+	format := flag.String("format", "text", "output format: text or json")
+	flag.Parse()
+
+	out, err := cli.NewOutput(os.Stdout, *format)
+	cli.Check(err)
+	make_values, ok := distributions[*distribution]
+	if !ok {
+		cli.Check(cli.Invalidf("unknown distribution %q", *distribution))
+	}
+	g := cli.Generator(*seed)
+
+	in := cli.Stdin()
+	num_items, err := in.IntOrFlag("items", *items_flag, "# Items: ")
+	cli.Check(err)
+	cli.Check(cli.ValidateArray(num_items, 1))
+
+	// Make and display the unsorted array.
+	out.Printf("Seed: %d\n", g.Seed())
+	arr := make_values(g, num_items)
+	sorting.PrintArray(out.Writer(), arr, *show)
+	out.Printf("\n")
+
+	// Bucket sort one copy and quicksort another to compare the work done.
+	var bucket_counts, quick_counts stats.Stats
+	quick := append([]float64(nil), arr...)
+	sorting.BucketSortStats(arr, &bucket_counts)
+	quicksort, _ := sorting.ByName[float64]("quicksort")
+	quicksort.SortStats(quick, sorting.LessFloat64, &quick_counts)
+
+	sorting.PrintArray(out.Writer(), arr, *show)
+	sorted := sorting.IsSortedFunc(arr, sorting.LessFloat64)
+	sorting.CheckSortedFunc(out.Writer(), arr, sorting.LessFloat64)
+	reports := []stats.Report{
+		stats.MakeReport("bucket_sort", num_items, &bucket_counts),
+		stats.MakeReport("quicksort", num_items, &quick_counts),
+	}
+	for _, report := range reports {
+		out.Printf("%v\n", report)
+	}
+
+	// JSON can't hold NaN or infinities, so the items are reported as text.
+	var head []string
+	for _, v := range cli.Head(arr, *show) {
+		head = append(head, strconv.FormatFloat(v, 'g', -1, 64))
+	}
+	cli.Check(out.Record(result{
+		Seed:         g.Seed(),
+		Items:        num_items,
+		Distribution: *distribution,
+		Head:         head,
+		Sorted:       sorted,
+		Reports:      reports,
+	}))
+	if !sorted {
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,200 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"example.com/m/v2/cli"
+	"example.com/m/v2/search"
+	"example.com/m/v2/sorting"
+)
+
+// The sorts to compare. The first is the baseline.
+var sorts = []struct {
+	name string
+	sort func([]int)
+}{
+	{"quicksort", sorting.Quicksort[int]},
+	{"block_quicksort", sorting.BlockQuicksort[int]},
+	{"block_quicksort_func", func(arr []int) { sorting.BlockQuicksortFunc(arr, sorting.Less[int]) }},
+}
+
+// The searches to compare. Each is built once per size and then returns the
+// lower bound of a target. The first is the baseline.
+var searches = []struct {
+	name  string
+	build func(sorted []int) func(target int) int
+}{
+	{"binary_search", func(sorted []int) func(int) int {
+		return func(target int) int {
+			index, _ := search.BinarySearch(sorted, target)
+			return index
+		}
+	}},
+	{"lower_bound", func(sorted []int) func(int) int {
+		return func(target int) int {
+			index, _ := search.LowerBound(sorted, target)
+			return index
+		}
+	}},
+	{"eytzinger", func(sorted []int) func(int) int {
+		e := search.NewEytzinger(sorted)
+		return func(target int) int {
+			index, _ := e.LowerBound(target)
+			return index
+		}
+	}},
+}
+
+// timing is one measurement in JSON mode.
+type timing struct {
+	Seed      int64   `json:"seed"`
+	Items     int     `json:"items"`
+	Bytes     int     `json:"bytes"`
+	Test      string  `json:"test"`
+	Algorithm string  `json:"algorithm"`
+	NsPerOp   float64 `json:"ns_per_op"`
+	Speedup   float64 `json:"speedup"`
+}
+
+func main() {
+	size_list := flag.String("sizes", "1024,16384,262144,4194304,16777216", "comma-separated numbers of items; the defaults run from L1-resident to well beyond L3")
+	lookups := flag.Int("lookups", 1000000, "searches to time at each size")
+	repeats := flag.Int("repeats", 3, "times to run each test; the fastest is reported")
+	seed := flag.Int64("seed", 0, "random seed (default: the current time)")
+	format := flag.String("format", "text", "output format: text or json")
+	flag.Parse()
+
+	out, err := cli.NewOutput(os.Stdout, *format)
+	cli.Check(err)
+	g := cli.Generator(*seed)
+	if *lookups < 1 || *repeats < 1 {
+		cli.Check(cli.Invalidf("-lookups and -repeats must be positive"))
+	}
+	var sizes []int
+	for _, field := range strings.Split(*size_list, ",") {
+		size, err := strconv.Atoi(strings.TrimSpace(field))
+		if err != nil || size < 1 {
+			cli.Check(cli.Invalidf("bad size %q", field))
+		}
+		sizes = append(sizes, size)
+	}
+
+	out.Printf("Seed: %d\n", g.Seed())
+	out.Printf("Sorts report ns per item and searches ns per lookup; speedups are against the first of each.\n\n")
+	out.Printf("%12s %10s  %-8s %-22s %10s %8s\n", "Items", "Memory", "Test", "Algorithm", "ns/op", "Speedup")
+
+	for _, n := range sizes {
+		// Values are spread over four times as many numbers as there are
+		// items, so about a quarter of the lookups hit.
+		arr := g.Uniform(n, 4*n)
+		targets := g.Uniform(*lookups, 4*n)
+		bytes := n * 8
+
+		record := func(test, algorithm string, per_op, baseline float64) {
+			out.Printf("%12d %10s  %-8s %-22s %10.2f %7.2fx\n", n, memory(bytes), test, algorithm, per_op, baseline/per_op)
+			cli.Check(out.Record(timing{
+				Seed: g.Seed(), Items: n, Bytes: bytes, Test: test,
+				Algorithm: algorithm, NsPerOp: per_op, Speedup: baseline / per_op,
+			}))
+		}
+
+		var baseline float64
+		for i, s := range sorts {
+			elapsed, err := time_sort(arr, s.sort, *repeats)
+			cli.Check(err)
+			per_item := float64(elapsed.Nanoseconds()) / float64(n)
+			if i == 0 {
+				baseline = per_item
+			}
+			record("sort", s.name, per_item, baseline)
+		}
+
+		sorted := append([]int(nil), arr...)
+		sorting.Quicksort(sorted)
+		for i, s := range searches {
+			elapsed, err := time_search(sorted, targets, s.build(sorted), *repeats)
+			cli.Check(err)
+			per_lookup := float64(elapsed.Nanoseconds()) / float64(len(targets))
+			if i == 0 {
+				baseline = per_lookup
+			}
+			record("search", s.name, per_lookup, baseline)
+		}
+	}
+}
+
+// time_sort sorts copies of arr and returns the fastest time.
+func time_sort(arr []int, sort func([]int), repeats int) (time.Duration, error) {
+	scratch := make([]int, len(arr))
+	var best time.Duration
+	for r := 0; r < repeats; r++ {
+		copy(scratch, arr)
+		start := time.Now()
+		sort(scratch)
+		elapsed := time.Since(start)
+
+		if !sorting.IsSorted(scratch) {
+			return 0, fmt.Errorf("the array is NOT sorted")
+		}
+		if r == 0 || elapsed < best {
+			best = elapsed
+		}
+	}
+	return best, nil
+}
+
+// time_search looks up every target and returns the fastest time. It also
+// checks that lower_bound agrees with search.LowerBound on the first
+// few targets.
+func time_search(sorted, targets []int, lower_bound func(target int) int, repeats int) (time.Duration, error) {
+	for _, target := range cli.Head(targets, 1000) {
+		want, _ := search.LowerBound(sorted, target)
+		got := lower_bound(target)
+
+		// Binary search returns any exact match, or -1 if there is none.
+		found := want < len(sorted) && sorted[want] == target
+		switch {
+		case got == want:
+		case got == -1 && !found:
+		case found && got >= 0 && got < len(sorted) && sorted[got] == target:
+		default:
+			return 0, fmt.Errorf("searching for %d gave %d, want %d", target, got, want)
+		}
+	}
+
+	var best time.Duration
+	sum := 0
+	for r := 0; r < repeats; r++ {
+		start := time.Now()
+		for _, target := range targets {
+			sum += lower_bound(target)
+		}
+		elapsed := time.Since(start)
+		if r == 0 || elapsed < best {
+			best = elapsed
+		}
+	}
+	// Use the sum so the compiler can't drop the searches.
+	if sum == -1 {
+		fmt.Fprintln(os.Stderr, sum)
+	}
+	return best, nil
+}
+
+// memory formats a number of bytes.
+func memory(bytes int) string {
+	switch {
+	case bytes >= 1<<30:
+		return fmt.Sprintf("%d GiB", bytes>>30)
+	case bytes >= 1<<20:
+		return fmt.Sprintf("%d MiB", bytes>>20)
+	case bytes >= 1<<10:
+		return fmt.Sprintf("%d KiB", bytes>>10)
+	}
+	return fmt.Sprintf("%d B", bytes)
+}
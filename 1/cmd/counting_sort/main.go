@@ -0,0 +1,80 @@
+package main
+
+import (
+	"flag"
+	"os"
+
+	"example.com/m/v2/cli"
+	"example.com/m/v2/datagen"
+	"example.com/m/v2/sorting"
+	"example.com/m/v2/stats"
+)
+
+// result is what the program reports in JSON mode.
+type result struct {
+	Seed      int64              `json:"seed"`
+	Algorithm string             `json:"algorithm"`
+	Items     int                `json:"items"`
+	Max       int                `json:"max"`
+	Head      []datagen.Customer `json:"head"`
+	Sorted    bool               `json:"sorted"`
+	Stats     stats.Stats        `json:"stats"`
+}
+
+func purchases(c datagen.Customer) int {
+	return c.NumPurchases
+}
+
+func fewer_purchases(a, b datagen.Customer) bool {
+	return a.NumPurchases < b.NumPurchases
+}
+
+func main() {
+	items_flag := flag.Int("items", 0, "number of customers to sort (read from stdin if not given)")
+	max_flag := flag.Int("max", 0, "purchase counts are chosen from [0, max) (read from stdin if not given)")
+	seed := flag.Int64("seed", 0, "random seed (default: the current time)")
+	show := flag.Int("show", 40, "number of customers to display")
+	format := flag.String("format", "text", "output format: text or json")
+	flag.Parse()
+
+	out, err := cli.NewOutput(os.Stdout, *format)
+	cli.Check(err)
+	g := cli.Generator(*seed)
+
+	// Get the number of items and maximum item value.
+	in := cli.Stdin()
+	num_items, err := in.IntOrFlag("items", *items_flag, "# Items: ")
+	cli.Check(err)
+	max, err := in.IntOrFlag("max", *max_flag, "Max: ")
+	cli.Check(err)
+	cli.Check(cli.ValidateArray(num_items, max))
+
+	// Make and display the unsorted array.
+	out.Printf("Seed: %d\n", g.Seed())
+	arr := g.Customers(num_items, max)
+	sorting.PrintArray(out.Writer(), arr, *show)
+	out.Printf("\n")
+
+	// Sort and display the result, counting the work done.
+	var counts stats.Stats
+	sorted := sorting.CountingSortStats(arr, 0, max, purchases, &counts)
+	sorting.PrintArray(out.Writer(), sorted, *show)
+
+	// Verify that it's sorted.
+	is_sorted := sorting.IsSortedFunc(sorted, fewer_purchases)
+	sorting.CheckSortedFunc(out.Writer(), sorted, fewer_purchases)
+	out.Printf("%v\n", stats.MakeReport("counting_sort", len(arr), &counts))
+
+	cli.Check(out.Record(result{
+		Seed:      g.Seed(),
+		Algorithm: "counting_sort",
+		Items:     num_items,
+		Max:       max,
+		Head:      cli.Head(sorted, *show),
+		Sorted:    is_sorted,
+		Stats:     counts,
+	}))
+	if !is_sorted {
+		os.Exit(1)
+	}
+}
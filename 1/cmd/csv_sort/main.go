@@ -0,0 +1,83 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"unicode/utf8"
+
+	"example.com/m/v2/cli"
+	"example.com/m/v2/csvsort"
+	"example.com/m/v2/stats"
+)
+
+func main() {
+	in_path := flag.String("in", "", "CSV file to sort (default: stdin)")
+	out_path := flag.String("out", "", "where to write the sorted CSV (default: stdout)")
+	keys_flag := flag.String("keys", "", "comma-separated sort keys, most significant first, each column[:type[:asc|desc]]\n"+
+		"where column is a header name or number and type is string, int, float or date (required)")
+	algorithm := flag.String("algorithm", "merge_sort", "sorting algorithm: "+strings.Join(csvsort.Algorithms(), ", "))
+	no_header := flag.Bool("no-header", false, "the first line is data rather than column names")
+	comma := flag.String("comma", ",", "field delimiter")
+	date_layout := flag.String("date-layout", "2006-01-02", "Go time layout of date columns")
+	show_stats := flag.Bool("stats", false, "report the work done by the sort on stderr")
+	flag.Parse()
+
+	keys, err := csvsort.ParseKeys(*keys_flag)
+	if err != nil {
+		cli.Check(cli.Invalidf("-keys: %v", err))
+	}
+	delimiter, size := utf8.DecodeRuneInString(*comma)
+	if size == 0 || size != len(*comma) {
+		cli.Check(cli.Invalidf("-comma must be a single character, got %q", *comma))
+	}
+	if !is_algorithm(*algorithm) {
+		cli.Check(cli.Invalidf("unknown algorithm %q", *algorithm))
+	}
+
+	var in io.Reader = os.Stdin
+	if *in_path != "" {
+		f, err := os.Open(*in_path)
+		cli.Check(err)
+		defer f.Close()
+		in = f
+	}
+	var out io.Writer = os.Stdout
+	var out_file *os.File
+	if *out_path != "" {
+		out_file, err = os.Create(*out_path)
+		cli.Check(err)
+		out = out_file
+	}
+
+	var s stats.Stats
+	opts := csvsort.Options{
+		Keys:       keys,
+		Algorithm:  *algorithm,
+		NoHeader:   *no_header,
+		Comma:      delimiter,
+		DateLayout: *date_layout,
+		Stats:      &s,
+	}
+	num_records, err := csvsort.Sort(in, out, opts)
+	cli.Check(err)
+	if out_file != nil {
+		cli.Check(out_file.Close())
+	}
+
+	if *show_stats {
+		fmt.Fprintln(os.Stderr, stats.MakeReport(*algorithm, num_records, &s))
+	}
+}
+
+// is_algorithm reports whether csvsort accepts the algorithm called name.
+func is_algorithm(name string) bool {
+	for _, a := range csvsort.Algorithms() {
+		if a == name {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,57 @@
+package main
+
+import (
+	"flag"
+	"os"
+	"time"
+
+	"example.com/m/v2/cli"
+	"example.com/m/v2/extsort"
+)
+
+// result is what the program reports in JSON mode.
+type result struct {
+	In           string  `json:"in"`
+	Out          string  `json:"out"`
+	FileFormat   string  `json:"file_format"`
+	MemoryBudget int     `json:"memory_budget"`
+	Seconds      float64 `json:"seconds"`
+}
+
+func main() {
+	in_path := flag.String("in", "", "file of integers to sort (required)")
+	out_path := flag.String("out", "", "where to write the sorted integers (required)")
+	file_format := flag.String("file-format", "text", "format of both files: text, binary32 or binary64")
+	memory_mb := flag.Int("mem", 64, "memory budget in MiB")
+	temp_dir := flag.String("tmp", "", "directory for temporary runs (default: system temp directory)")
+	format := flag.String("format", "text", "output format: text or json")
+	flag.Parse()
+
+	out, err := cli.NewOutput(os.Stdout, *format)
+	cli.Check(err)
+	if *in_path == "" || *out_path == "" {
+		cli.Check(cli.Invalidf("-in and -out are required"))
+	}
+	encoding, err := extsort.ParseFormat(*file_format)
+	if err != nil {
+		cli.Check(cli.Invalidf("%v", err))
+	}
+
+	opts := extsort.Options{
+		Format:       encoding,
+		MemoryBudget: *memory_mb << 20,
+		TempDir:      *temp_dir,
+	}
+	start := time.Now()
+	cli.Check(extsort.SortFile(*in_path, *out_path, opts))
+	elapsed := time.Since(start)
+
+	out.Printf("Sorted %s into %s in %.3f seconds\n", *in_path, *out_path, elapsed.Seconds())
+	cli.Check(out.Record(result{
+		In:           *in_path,
+		Out:          *out_path,
+		FileFormat:   encoding.String(),
+		MemoryBudget: opts.MemoryBudget,
+		Seconds:      elapsed.Seconds(),
+	}))
+}
@@ -0,0 +1,112 @@
+package main
+
+import (
+	"flag"
+	"os"
+	"strconv"
+
+	"example.com/m/v2/cli"
+	"example.com/m/v2/extsort"
+	"example.com/m/v2/search"
+)
+
+// result is what the program reports for each target in JSON mode.
+type result struct {
+	Target string `json:"target"`
+	// Position is the record number in a binary file or the byte offset of
+	// the line in a text file, or -1 if the target is missing.
+	Position int64 `json:"position"`
+	NumTests int   `json:"num_tests"`
+}
+
+// finder looks for a target given as text and returns where it is.
+type finder func(target string) (position int64, num_tests int, err error)
+
+func main() {
+	path := flag.String("file", "", "sorted file to search (required)")
+	file_format := flag.String("file-format", "text", "file format: text, binary32 or binary64, as written by external_sort")
+	string_keys := flag.Bool("string-keys", false, "compare the lines of a text file as strings rather than integers")
+	index_every := flag.Int("index", 0, "keep every n'th key in memory to cut disk reads (0 for no index)")
+	targets := flag.String("targets", "", "comma-separated values to search for (read from stdin if not given)")
+	format := flag.String("format", "text", "output format: text or json")
+	flag.Parse()
+
+	out, err := cli.NewOutput(os.Stdout, *format)
+	cli.Check(err)
+	if *path == "" {
+		cli.Check(cli.Invalidf("-file is required"))
+	}
+	encoding, err := extsort.ParseFormat(*file_format)
+	if err != nil {
+		cli.Check(cli.Invalidf("%v", err))
+	}
+	if *index_every < 0 {
+		cli.Check(cli.Invalidf("-index must not be negative, got %d", *index_every))
+	}
+
+	file, err := os.Open(*path)
+	cli.Check(err)
+	defer file.Close()
+	info, err := file.Stat()
+	cli.Check(err)
+
+	var find finder
+	switch {
+	case encoding == extsort.Text && *string_keys:
+		find = line_finder(search.NewLineFile(file, info.Size(), search.StringKey), *index_every,
+			func(s string) (string, error) { return s, nil })
+	case encoding == extsort.Text:
+		find = line_finder(search.NewLineFile(file, info.Size(), search.IntKey), *index_every, parse_int)
+	default:
+		record_size, key := 8, search.Int64Key
+		if encoding == extsort.Binary32 {
+			record_size, key = 4, search.Int32Key
+		}
+		f, err := search.NewRecordFile(file, info.Size(), record_size, key)
+		cli.Check(err)
+		if *index_every > 0 {
+			cli.Check(f.BuildIndex(*index_every))
+		}
+		find = func(target string) (int64, int, error) {
+			v, err := parse_int(target)
+			if err != nil {
+				return -1, 0, err
+			}
+			index, num_tests, err := f.Find(v)
+			return int64(index), num_tests, err
+		}
+	}
+
+	in := cli.Stdin()
+	err = in.EachString("targets", *targets, "Target: ", func(target string) error {
+		position, num_tests, err := find(target)
+		if err != nil {
+			return err
+		}
+		out.Printf("Target: %s\nPosition: %d\nNum tests: %d\n", target, position, num_tests)
+		return out.Record(result{Target: target, Position: position, NumTests: num_tests})
+	})
+	cli.Check(err)
+}
+
+// line_finder searches a text file, parsing targets with parse.
+func line_finder[K int64 | string](f *search.LineFile[K], index_every int, parse func(string) (K, error)) finder {
+	if index_every > 0 {
+		cli.Check(f.BuildIndex(index_every))
+	}
+	return func(target string) (int64, int, error) {
+		k, err := parse(target)
+		if err != nil {
+			return -1, 0, err
+		}
+		return f.Find(k)
+	}
+}
+
+func parse_int(s string) (int64, error) {
+	v, err := strconv.ParseInt(s, 10, 64)
+	if err != nil {
+		return 0, cli.Invalidf("%q is not an integer", s)
+	}
+	return v, nil
+}
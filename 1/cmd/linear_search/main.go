@@ -0,0 +1,76 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"os"
+	"time"
+
+	"example.com/m/v2/cli"
+	"example.com/m/v2/search"
+	"example.com/m/v2/sorting"
+)
+
+// result is what the program reports for each target in JSON mode.
+type result struct {
+	Seed     int64 `json:"seed"`
+	Target   int   `json:"target"`
+	Index    int   `json:"index"`
+	NumTests int   `json:"num_tests"`
+}
+
+func main() {
+	items_flag := flag.Int("items", 0, "number of items to search (read from stdin if not given)")
+	max_flag := flag.Int("max", 0, "items are chosen from [0, max) (read from stdin if not given)")
+	targets := flag.String("targets", "", "comma-separated values to search for (read from stdin if not given)")
+	seed := flag.Int64("seed", 0, "random seed (default: the current time)")
+	show := flag.Int("show", 40, "number of items to display")
+	format := flag.String("format", "text", "output format: text or json")
+	parallelism := flag.Int("parallelism", 1, "goroutines to search with (0 for one per CPU); 1 uses the plain linear search")
+	timeout := flag.Duration("timeout", 0, "give up on a parallel search after this long (0 for no limit)")
+	flag.Parse()
+
+	out, err := cli.NewOutput(os.Stdout, *format)
+	cli.Check(err)
+	g := cli.Generator(*seed)
+
+	// Get the number of items and maximum item value.
+	in := cli.Stdin()
+	num_items, err := in.IntOrFlag("items", *items_flag, "# Items: ")
+	cli.Check(err)
+	max, err := in.IntOrFlag("max", *max_flag, "Max: ")
+	cli.Check(err)
+	cli.Check(cli.ValidateArray(num_items, max))
+
+	// Make and display the unsorted array.
+	out.Printf("Seed: %d\n", g.Seed())
+	arr := g.Uniform(num_items, max)
+	sorting.PrintArray(out.Writer(), arr, *show)
+	out.Printf("\n")
+
+	err = in.EachInt("targets", *targets, "Target: ", func(target int) error {
+		index, num_tests, err := find(arr, target, *parallelism, *timeout)
+		if err != nil {
+			return err
+		}
+		out.Printf("Index: %d\nNum tests: %d\n", index, num_tests)
+		return out.Record(result{Seed: g.Seed(), Target: target, Index: index, NumTests: num_tests})
+	})
+	cli.Check(err)
+}
+
+// find searches arr for target, on several goroutines unless parallelism is 1.
+func find(arr []int, target, parallelism int, timeout time.Duration) (index, num_tests int, err error) {
+	if parallelism == 1 {
+		index, num_tests = search.LinearSearch(arr, target)
+		return index, num_tests, nil
+	}
+
+	ctx := context.Background()
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+	return search.ParallelLinearSearch(ctx, arr, target, parallelism)
+}
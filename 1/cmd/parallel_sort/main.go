@@ -0,0 +1,88 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"time"
+
+	"example.com/m/v2/cli"
+	"example.com/m/v2/sorting"
+)
+
+// timing is one sort's time in JSON mode.
+type timing struct {
+	Seed        int64  `json:"seed"`
+	Algorithm   string `json:"algorithm"`
+	Nanoseconds int64  `json:"nanoseconds"`
+}
+
+// time_sort sorts a copy of arr with sort and returns how long it took.
+func time_sort(arr []int, sort func([]int)) (time.Duration, error) {
+	scratch := make([]int, len(arr))
+	copy(scratch, arr)
+
+	start := time.Now()
+	sort(scratch)
+	elapsed := time.Since(start)
+
+	if !sorting.IsSorted(scratch) {
+		return 0, fmt.Errorf("the array is NOT sorted")
+	}
+	return elapsed, nil
+}
+
+func main() {
+	items_flag := flag.Int("items", 0, "number of items to sort (read from stdin if not given)")
+	max_flag := flag.Int("max", 0, "items are chosen from [0, max) (read from stdin if not given)")
+	parallelism_flag := flag.Int("parallelism", 0, "goroutines to use, 0 for GOMAXPROCS (read from stdin if not given)")
+	seed := flag.Int64("seed", 0, "random seed (default: the current time)")
+	format := flag.String("format", "text", "output format: text or json")
+	flag.Parse()
+
+	out, err := cli.NewOutput(os.Stdout, *format)
+	cli.Check(err)
+	g := cli.Generator(*seed)
+
+	// Get the number of items, maximum item value and number of goroutines.
+	in := cli.Stdin()
+	num_items, err := in.IntOrFlag("items", *items_flag, "# Items: ")
+	cli.Check(err)
+	max, err := in.IntOrFlag("max", *max_flag, "Max: ")
+	cli.Check(err)
+	cli.Check(cli.ValidateArray(num_items, max))
+	parallelism, err := in.IntOrFlag("parallelism", *parallelism_flag, "Parallelism (0 for GOMAXPROCS): ")
+	cli.Check(err)
+	if parallelism < 0 {
+		cli.Check(cli.Invalidf("parallelism must not be negative, got %d", parallelism))
+	}
+
+	out.Printf("Seed: %d\n", g.Seed())
+	arr := g.Uniform(num_items, max)
+	out.Printf("\n")
+
+	// Time each sort against its parallel counterpart.
+	sorts := []struct {
+		name string
+		sort func([]int)
+	}{
+		{"Quicksort", sorting.Quicksort[int]},
+		{"Parallel quicksort", func(a []int) { sorting.ParallelQuicksort(a, parallelism) }},
+		{"Merge sort", sorting.MergeSort[int]},
+		{"Parallel merge sort", func(a []int) { sorting.ParallelMergeSort(a, parallelism) }},
+	}
+	var sequential time.Duration
+	for i, s := range sorts {
+		elapsed, err := time_sort(arr, s.sort)
+		cli.Check(err)
+
+		// Even entries are sequential and odd ones are their parallel versions.
+		if i%2 == 0 {
+			sequential = elapsed
+			out.Printf("%-20s %v\n", s.name+":", elapsed)
+		} else {
+			out.Printf("%-20s %v (%.2fx)\n", s.name+":", elapsed, float64(sequential)/float64(elapsed))
+		}
+		cli.Check(out.Record(timing{Seed: g.Seed(), Algorithm: s.name, Nanoseconds: elapsed.Nanoseconds()}))
+	}
+}
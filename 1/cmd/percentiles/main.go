@@ -0,0 +1,92 @@
+package main
+
+import (
+	"flag"
+	"os"
+	"strconv"
+	"strings"
+
+	"example.com/m/v2/cli"
+	"example.com/m/v2/sorting"
+)
+
+// percentile is one requested percentile and its value.
+type percentile struct {
+	Percent float64 `json:"percent"`
+	Value   int     `json:"value"`
+}
+
+// result is what the program reports in JSON mode.
+type result struct {
+	Seed        int64        `json:"seed"`
+	Items       int          `json:"items"`
+	Max         int          `json:"max"`
+	Median      int          `json:"median"`
+	Percentiles []percentile `json:"percentiles"`
+}
+
+func main() {
+	items_flag := flag.Int("items", 0, "number of items (read from stdin if not given)")
+	max_flag := flag.Int("max", 0, "items are chosen from [0, max) (read from stdin if not given)")
+	list := flag.String("percentiles", "1,10,25,50,75,90,99", "comma-separated percentiles to find")
+	show := flag.Int("show", 40, "number of items to display")
+	seed := flag.Int64("seed", 0, "random seed (default: the current time)")
+	format := flag.String("format", "text", "output format: text or json")
+	flag.Parse()
+
+	out, err := cli.NewOutput(os.Stdout, *format)
+	cli.Check(err)
+	percents, err := parse_percents(*list)
+	cli.Check(err)
+	g := cli.Generator(*seed)
+
+	// Get the number of items and maximum item value.
+	in := cli.Stdin()
+	num_items, err := in.IntOrFlag("items", *items_flag, "# Items: ")
+	cli.Check(err)
+	max, err := in.IntOrFlag("max", *max_flag, "Max: ")
+	cli.Check(err)
+	cli.Check(cli.ValidateArray(num_items, max))
+	if num_items == 0 {
+		cli.Check(cli.Invalidf("need at least one item"))
+	}
+
+	// Make and display the unsorted array.
+	out.Printf("Seed: %d\n", g.Seed())
+	arr := g.Uniform(num_items, max)
+	sorting.PrintArray(out.Writer(), arr, *show)
+	out.Printf("\n")
+
+	// Find all of the percentiles with one multi-select instead of sorting.
+	ranks := make([]int, len(percents))
+	for i, p := range percents {
+		ranks[i] = sorting.PercentileRank(p, num_items)
+	}
+	values := sorting.MultiSelect(arr, ranks)
+	median := sorting.Median(arr)
+
+	res := result{Seed: g.Seed(), Items: num_items, Max: max, Median: median}
+	out.Printf("Median: %d\n", median)
+	for i, p := range percents {
+		out.Printf("P%-6v %d\n", p, values[i])
+		res.Percentiles = append(res.Percentiles, percentile{Percent: p, Value: values[i]})
+	}
+	cli.Check(out.Record(res))
+}
+
+// parse_percents parses a comma-separated list of percentiles.
+func parse_percents(list string) ([]float64, error) {
+	var percents []float64
+	for _, field := range strings.Split(list, ",") {
+		field = strings.TrimSpace(field)
+		if field == "" {
+			continue
+		}
+		p, err := strconv.ParseFloat(field, 64)
+		if err != nil || !(p >= 0 && p <= 100) {
+			return nil, cli.Invalidf("bad percentile %q", field)
+		}
+		percents = append(percents, p)
+	}
+	return percents, nil
+}
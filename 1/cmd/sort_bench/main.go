@@ -0,0 +1,74 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"example.com/m/v2/bench"
+	"example.com/m/v2/datagen"
+)
+
+func main() {
+	algorithm_names := flag.String("algorithms", "all", "comma-separated algorithms to run, or all")
+	distribution_names := flag.String("distributions", "all", "comma-separated input distributions, or all")
+	size_list := flag.String("sizes", "1000,10000,100000", "comma-separated input sizes")
+	format := flag.String("format", "csv", "output format: text, json or csv")
+	seed := flag.Int64("seed", 1, "random seed for the inputs")
+	repeats := flag.Int("repeats", 3, "times to run each sort; the fastest is reported")
+	quadratic_limit := flag.Int("quadratic-limit", 20000, "largest size for O(n²) sorts, 0 for no limit")
+	flag.Parse()
+
+	config := bench.Config{
+		Seed:           *seed,
+		Repeats:        *repeats,
+		QuadraticLimit: *quadratic_limit,
+	}
+
+	if *algorithm_names == "all" {
+		config.Algorithms = bench.Algorithms()
+	} else {
+		for _, name := range strings.Split(*algorithm_names, ",") {
+			alg, err := bench.AlgorithmByName(strings.TrimSpace(name))
+			if err != nil {
+				fail(err)
+			}
+			config.Algorithms = append(config.Algorithms, alg)
+		}
+	}
+
+	if *distribution_names == "all" {
+		config.Distributions = datagen.Distributions
+	} else {
+		for _, name := range strings.Split(*distribution_names, ",") {
+			dist, err := datagen.DistributionByName(strings.TrimSpace(name))
+			if err != nil {
+				fail(err)
+			}
+			config.Distributions = append(config.Distributions, dist)
+		}
+	}
+
+	for _, field := range strings.Split(*size_list, ",") {
+		size, err := strconv.Atoi(strings.TrimSpace(field))
+		if err != nil || size < 0 {
+			fail(fmt.Errorf("bad size %q", field))
+		}
+		config.Sizes = append(config.Sizes, size)
+	}
+
+	results, err := bench.Run(config)
+	if err != nil {
+		fail(err)
+	}
+	if err := bench.WriteResults(os.Stdout, *format, results); err != nil {
+		fail(err)
+	}
+}
+
+func fail(err error) {
+	fmt.Fprintln(os.Stderr, "sort_bench:", err)
+	os.Exit(1)
+}
@@ -0,0 +1,83 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"example.com/m/v2/cli"
+	"example.com/m/v2/sorting"
+	"example.com/m/v2/trace"
+)
+
+func main() {
+	algorithm := flag.String("algorithm", "bubble_sort", "sorting algorithm: "+strings.Join(sorting.Names(), ", "))
+	items_flag := flag.Int("items", 0, "number of items to sort (read from stdin if not given)")
+	max_flag := flag.Int("max", 0, "items are chosen from [0, max) (read from stdin if not given)")
+	seed := flag.Int64("seed", 0, "random seed (default: the current time)")
+	format := flag.String("format", "ascii", "output format: ascii, svg or json")
+	replay := flag.String("replay", "", "replay a trace saved with -format json instead of sorting")
+	delay := flag.Duration("delay", 300*time.Millisecond, "pause between frames when animating in a terminal")
+	height := flag.Int("height", 10, "rows used for the bars in ascii output")
+	svg_dir := flag.String("svg-dir", "frames", "directory for the frames written by -format svg")
+	flag.Parse()
+
+	if *format != "ascii" && *format != "svg" && *format != "json" {
+		cli.Check(cli.Invalidf("unknown format %q (want ascii, svg or json)", *format))
+	}
+
+	var t *trace.Trace
+	if *replay != "" {
+		t = load(*replay)
+	} else {
+		g := cli.Generator(*seed)
+
+		// Get the number of items and maximum item value.
+		in := cli.Stdin()
+		num_items, err := in.IntOrFlag("items", *items_flag, "# Items: ")
+		cli.Check(err)
+		max, err := in.IntOrFlag("max", *max_flag, "Max: ")
+		cli.Check(err)
+		cli.Check(cli.ValidateArray(num_items, max))
+
+		t, err = trace.Record(*algorithm, g.Uniform(num_items, max))
+		if err != nil {
+			cli.Check(cli.Invalidf("%v", err))
+		}
+	}
+
+	switch *format {
+	case "json":
+		cli.Check(json.NewEncoder(os.Stdout).Encode(t))
+	case "svg":
+		n, err := trace.WriteSVGFrames(*svg_dir, t, trace.SVGOptions{})
+		cli.Check(err)
+		fmt.Printf("Wrote %d frames to %s\n", n, *svg_dir)
+	default:
+		opts := trace.ASCIIOptions{Height: *height}
+		if cli.IsTerminal(os.Stdout) {
+			cli.Check(trace.Animate(os.Stdout, t, *delay, opts))
+		} else {
+			cli.Check(trace.WriteASCII(os.Stdout, t, opts))
+		}
+	}
+}
+
+// load reads a trace saved with -format json and checks that it can be replayed.
+func load(path string) *trace.Trace {
+	f, err := os.Open(path)
+	cli.Check(err)
+	defer f.Close()
+
+	var t trace.Trace
+	if err := json.NewDecoder(f).Decode(&t); err != nil {
+		cli.Check(cli.Invalidf("%s: %v", path, err))
+	}
+	if err := t.Validate(); err != nil {
+		cli.Check(cli.Invalidf("%s: %v", path, err))
+	}
+	return &t
+}
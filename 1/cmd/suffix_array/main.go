@@ -0,0 +1,82 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"time"
+
+	"example.com/m/v2/cli"
+	"example.com/m/v2/search"
+	"example.com/m/v2/sorting"
+)
+
+// result is what the program reports for each pattern in JSON mode.
+type result struct {
+	Pattern  string `json:"pattern"`
+	Count    int    `json:"count"`
+	Indexes  []int  `json:"indexes"`
+	NumTests int    `json:"num_tests"`
+}
+
+func main() {
+	text_flag := flag.String("text", "", "text to index")
+	path := flag.String("file", "", "file to index instead of -text")
+	patterns := flag.String("patterns", "", "comma-separated substrings to look for (read from stdin if not given)")
+	compare := flag.Bool("compare", true, "also build the suffix array by prefix doubling and check that it agrees")
+	show := flag.Int("show", 20, "number of suffixes and occurrences to display")
+	format := flag.String("format", "text", "output format: text or json")
+	flag.Parse()
+
+	out, err := cli.NewOutput(os.Stdout, *format)
+	cli.Check(err)
+
+	text := *text_flag
+	if *path != "" {
+		data, err := os.ReadFile(*path)
+		cli.Check(err)
+		text = string(data)
+	}
+
+	start := time.Now()
+	x := search.NewSuffixIndex(text)
+	out.Printf("Length: %d\n", len(text))
+	out.Printf("SA-IS and LCP: %v\n", time.Since(start))
+
+	if *compare {
+		start = time.Now()
+		doubling := sorting.SuffixArrayDoubling(text)
+		out.Printf("Prefix doubling: %v\n", time.Since(start))
+		for i, v := range doubling {
+			if v != x.SuffixArray()[i] {
+				cli.Check(fmt.Errorf("the suffix arrays differ at %d", i))
+			}
+		}
+	}
+
+	out.Printf("\n")
+	out.Printf("%8s %6s  %s\n", "Index", "LCP", "Suffix")
+	for i, v := range cli.Head(x.SuffixArray(), *show) {
+		out.Printf("%8d %6d  %q\n", v, x.LCP()[i], clip(text[v:], 40))
+	}
+	if substring, index := x.LongestRepeated(); index >= 0 {
+		out.Printf("\nLongest repeated substring: %q at %d\n", clip(substring, 60), index)
+	}
+	out.Printf("\n")
+
+	in := cli.Stdin()
+	err = in.EachString("patterns", *patterns, "Pattern: ", func(pattern string) error {
+		indexes, num_tests := x.Find(pattern)
+		out.Printf("Count: %d\nIndexes: %v\nNum tests: %d\n", len(indexes), cli.Head(indexes, *show), num_tests)
+		return out.Record(result{Pattern: pattern, Count: len(indexes), Indexes: indexes, NumTests: num_tests})
+	})
+	cli.Check(err)
+}
+
+// clip shortens s to at most n bytes.
+func clip(s string, n int) string {
+	if len(s) > n {
+		return s[:n] + "..."
+	}
+	return s
+}
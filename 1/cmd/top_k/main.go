@@ -0,0 +1,58 @@
+package main
+
+import (
+	"flag"
+	"os"
+
+	"example.com/m/v2/cli"
+	"example.com/m/v2/sorting"
+)
+
+// result is what the program reports in JSON mode.
+type result struct {
+	Seed     int64 `json:"seed"`
+	Items    int   `json:"items"`
+	Max      int   `json:"max"`
+	Smallest []int `json:"smallest"`
+	Largest  []int `json:"largest"`
+}
+
+func main() {
+	items_flag := flag.Int("items", 0, "number of items (read from stdin if not given)")
+	max_flag := flag.Int("max", 0, "items are chosen from [0, max) (read from stdin if not given)")
+	k := flag.Int("k", 40, "number of smallest and largest items to find")
+	seed := flag.Int64("seed", 0, "random seed (default: the current time)")
+	format := flag.String("format", "text", "output format: text or json")
+	flag.Parse()
+
+	out, err := cli.NewOutput(os.Stdout, *format)
+	cli.Check(err)
+	if *k < 0 {
+		cli.Check(cli.Invalidf("-k must not be negative, got %d", *k))
+	}
+	g := cli.Generator(*seed)
+
+	// Get the number of items and maximum item value.
+	in := cli.Stdin()
+	num_items, err := in.IntOrFlag("items", *items_flag, "# Items: ")
+	cli.Check(err)
+	max, err := in.IntOrFlag("max", *max_flag, "Max: ")
+	cli.Check(err)
+	cli.Check(cli.ValidateArray(num_items, max))
+
+	// Make and display the unsorted array.
+	out.Printf("Seed: %d\n", g.Seed())
+	arr := g.Uniform(num_items, max)
+	sorting.PrintArray(out.Writer(), arr, *k)
+	out.Printf("\n")
+
+	// Find the extremes with a heap of k items instead of sorting everything.
+	smallest := sorting.Smallest(arr, *k)
+	largest := sorting.Largest(arr, *k)
+	out.Printf("Smallest %d:\n", len(smallest))
+	sorting.PrintArray(out.Writer(), smallest, len(smallest))
+	out.Printf("Largest %d:\n", len(largest))
+	sorting.PrintArray(out.Writer(), largest, len(largest))
+
+	cli.Check(out.Record(result{Seed: g.Seed(), Items: num_items, Max: max, Smallest: smallest, Largest: largest}))
+}
@@ -0,0 +1,251 @@
+// Package csvsort sorts the records of a CSV file by one or more typed columns.
+//
+// Records are read and their keys parsed one at a time as the input streams
+// in, and the sorted records are streamed back out as they are written, but
+// the records themselves must fit in memory while they are sorted.
+package csvsort
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+
+	"example.com/m/v2/sorting"
+	"example.com/m/v2/stats"
+)
+
+// The algorithm used when Options.Algorithm is empty. It is stable, so
+// records with equal keys keep their input order.
+const default_algorithm = "merge_sort"
+
+// The date layout used when Options.DateLayout is empty.
+const default_date_layout = "2006-01-02"
+
+// Counting sort allocates one counter per possible key, so it refuses keys
+// that span more values than this.
+const counting_sort_limit = 1 << 20
+
+// Options control a CSV sort.
+type Options struct {
+	// Keys are the columns to sort by, most significant first.
+	Keys []Key
+	// Algorithm is any name from Algorithms. It defaults to merge_sort.
+	Algorithm string
+	// NoHeader says the first record is data rather than column names.
+	// Keys must then give column numbers.
+	NoHeader bool
+	// Comma is the field delimiter. It defaults to ','.
+	Comma rune
+	// DateLayout is the time.Parse layout of Date columns. It defaults to
+	// 2006-01-02.
+	DateLayout string
+	// Stats, if not nil, records the work done by the sort.
+	Stats *stats.Stats
+}
+
+// Algorithms returns the names of the algorithms Sort accepts: every
+// registered comparison sort plus counting_sort and radix_sort, which only
+// work when every key is an int column.
+func Algorithms() []string {
+	return append(sorting.Names(), "counting_sort", "radix_sort")
+}
+
+// record is one CSV record and its parsed keys.
+type record struct {
+	fields []string
+	values []value
+}
+
+// Sort reads CSV records from r, sorts them by opts.Keys and writes them to w.
+// The header, if there is one, is written first. It returns the number of
+// records sorted, not counting the header.
+func Sort(r io.Reader, w io.Writer, opts Options) (int, error) {
+	if len(opts.Keys) == 0 {
+		return 0, fmt.Errorf("csvsort: no sort keys given")
+	}
+	if opts.Algorithm == "" {
+		opts.Algorithm = default_algorithm
+	}
+	if opts.Comma == 0 {
+		opts.Comma = ','
+	}
+	if opts.DateLayout == "" {
+		opts.DateLayout = default_date_layout
+	}
+	sort, err := make_sort(opts)
+	if err != nil {
+		return 0, err
+	}
+
+	reader := csv.NewReader(r)
+	reader.Comma = opts.Comma
+	writer := csv.NewWriter(w)
+	writer.Comma = opts.Comma
+
+	var header []string
+	if !opts.NoHeader {
+		header, err = reader.Read()
+		if err == io.EOF {
+			return 0, nil
+		}
+		if err != nil {
+			return 0, err
+		}
+	}
+	columns, err := resolve_columns(opts.Keys, header)
+	if err != nil {
+		return 0, err
+	}
+
+	records, err := read_records(reader, columns, opts)
+	if err != nil {
+		return 0, err
+	}
+	if err := sort(records); err != nil {
+		return 0, err
+	}
+
+	if header != nil {
+		if err := writer.Write(header); err != nil {
+			return 0, err
+		}
+	}
+	for _, rec := range records {
+		if err := writer.Write(rec.fields); err != nil {
+			return 0, err
+		}
+	}
+	writer.Flush()
+	return len(records), writer.Error()
+}
+
+// resolve_columns returns the zero-based column index of each key.
+func resolve_columns(keys []Key, header []string) ([]int, error) {
+	columns := make([]int, len(keys))
+	for k, key := range keys {
+		columns[k] = -1
+		for i, name := range header {
+			if name == key.Column {
+				columns[k] = i
+				break
+			}
+		}
+		if columns[k] >= 0 {
+			continue
+		}
+
+		n, err := strconv.Atoi(key.Column)
+		if err != nil || n < 1 {
+			if header == nil {
+				return nil, fmt.Errorf("csvsort: column %q must be a number when there is no header", key.Column)
+			}
+			return nil, fmt.Errorf("csvsort: no column called %q", key.Column)
+		}
+		if header != nil && n > len(header) {
+			return nil, fmt.Errorf("csvsort: column %d is past the last column, %d", n, len(header))
+		}
+		columns[k] = n - 1
+	}
+	return columns, nil
+}
+
+// read_records reads the rest of the input and parses each record's keys.
+func read_records(reader *csv.Reader, columns []int, opts Options) ([]record, error) {
+	var records []record
+	for {
+		fields, err := reader.Read()
+		if err == io.EOF {
+			return records, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		rec := record{fields: fields, values: make([]value, len(columns))}
+		line, _ := reader.FieldPos(0)
+		for k, column := range columns {
+			if column >= len(fields) {
+				return nil, fmt.Errorf("csvsort: line %d: no column %d", line, column+1)
+			}
+			v, err := parse_value(fields[column], opts.Keys[k].Type, opts.DateLayout)
+			if err != nil {
+				return nil, fmt.Errorf("csvsort: line %d: column %d: %q is not a valid %v",
+					line, column+1, fields[column], opts.Keys[k].Type)
+			}
+			rec.values[k] = v
+		}
+		records = append(records, rec)
+	}
+}
+
+// make_sort returns a function that sorts records with the chosen algorithm.
+func make_sort(opts Options) (func(records []record) error, error) {
+	switch opts.Algorithm {
+	case "counting_sort", "radix_sort":
+		for _, key := range opts.Keys {
+			if key.Type != Int {
+				return nil, fmt.Errorf("csvsort: %s needs int keys, but column %q is %v",
+					opts.Algorithm, key.Column, key.Type)
+			}
+		}
+		return func(records []record) error {
+			return sort_int_keys(records, opts)
+		}, nil
+	}
+
+	sorter, err := sorting.ByName[record](opts.Algorithm)
+	if err != nil {
+		return nil, err
+	}
+	comparators := make([]sorting.Comparator[record], len(opts.Keys))
+	for k, key := range opts.Keys {
+		comparators[k] = comparator(k, key)
+	}
+	less := sorting.Chain(comparators...).Less
+	return func(records []record) error {
+		sorter.SortStats(records, less, opts.Stats)
+		return nil
+	}, nil
+}
+
+// sort_int_keys sorts records by their int keys with one stable counting or
+// radix sort per key, starting with the least significant key.
+func sort_int_keys(records []record, opts Options) error {
+	if len(records) <= 1 {
+		return nil
+	}
+	for k := len(opts.Keys) - 1; k >= 0; k-- {
+		k := k
+		key := func(r record) int64 { return r.values[k].i }
+		if opts.Keys[k].Descending {
+			// Flipping the bits reverses the order without overflowing.
+			key = func(r record) int64 { return ^r.values[k].i }
+		}
+
+		if opts.Algorithm == "radix_sort" {
+			sorting.RadixSortFunc(records, key)
+			continue
+		}
+
+		min_key, max_key := int64(math.MaxInt64), int64(math.MinInt64)
+		for _, r := range records {
+			if v := key(r); v < min_key {
+				min_key = v
+			}
+			if v := key(r); v > max_key {
+				max_key = v
+			}
+		}
+		if uint64(max_key)-uint64(min_key) >= counting_sort_limit {
+			return fmt.Errorf("csvsort: column %q spans too many values for counting_sort; use radix_sort",
+				opts.Keys[k].Column)
+		}
+		sorted := sorting.CountingSort(records, 0, int(max_key-min_key)+1, func(r record) int {
+			return int(key(r) - min_key)
+		})
+		copy(records, sorted)
+	}
+	return nil
+}
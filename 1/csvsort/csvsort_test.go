@@ -0,0 +1,160 @@
+package csvsort
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+
+	"example.com/m/v2/datagen"
+)
+
+// TestAlgorithmsAgree sorts random CSV files by two int keys with each of the
+// stable algorithms and checks that they all write the same file.
+func TestAlgorithmsAgree(t *testing.T) {
+	for seed := int64(0); seed < 200; seed++ {
+		r := datagen.New(seed)
+		var input bytes.Buffer
+		input.WriteString("id,a,b\n")
+		spread := r.Intn(20) + 1
+		for i := r.Intn(201); i > 0; i-- {
+			fmt.Fprintf(&input, "%d,%d,%d\n", i, r.Intn(spread)-spread/2, r.Intn(spread))
+		}
+		keys := []Key{
+			{Column: "a", Type: Int, Descending: r.Intn(2) == 0},
+			{Column: "b", Type: Int, Descending: r.Intn(2) == 0},
+		}
+
+		var want string
+		for _, algorithm := range []string{"merge_sort", "timsort", "counting_sort", "radix_sort"} {
+			var output bytes.Buffer
+			opts := Options{Keys: keys, Algorithm: algorithm}
+			if _, err := Sort(bytes.NewReader(input.Bytes()), &output, opts); err != nil {
+				t.Fatalf("seed %d: %s: %v", seed, algorithm, err)
+			}
+			if want == "" {
+				want = output.String()
+			} else if output.String() != want {
+				t.Fatalf("seed %d: %s sorted %q by %v to %q, want %q", seed, algorithm, input.String(), keys, output.String(), want)
+			}
+		}
+	}
+}
+
+// TestSort checks the output of small sorts against what they should write.
+func TestSort(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		opts  Options
+		want  string
+	}{
+		{
+			name:  "float keys put NaN first",
+			input: "name,score\na,2.5\nb,NaN\nc,-1\nd,nan\ne,1e1\n",
+			opts:  Options{Keys: []Key{{Column: "score", Type: Float}}},
+			want:  "name,score\nb,NaN\nd,nan\nc,-1\na,2.5\ne,1e1\n",
+		},
+		{
+			name:  "date keys",
+			input: "event,when\nc,2021-03-01\na,2020-12-31\nb,2021-01-15\n",
+			opts:  Options{Keys: []Key{{Column: "when", Type: Date}}},
+			want:  "event,when\na,2020-12-31\nb,2021-01-15\nc,2021-03-01\n",
+		},
+		{
+			name:  "date keys in another layout",
+			input: "event,when\nc,03/01/2021\na,12/31/2020\nb,01/15/2021\n",
+			opts:  Options{Keys: []Key{{Column: "when", Type: Date}}, DateLayout: "01/02/2006"},
+			want:  "event,when\na,12/31/2020\nb,01/15/2021\nc,03/01/2021\n",
+		},
+		{
+			name:  "string keys compare bytes",
+			input: "word\nb\nB\na\nA\nab\n",
+			opts:  Options{Keys: []Key{{Column: "word"}}},
+			want:  "word\nA\nB\na\nab\nb\n",
+		},
+		{
+			name:  "descending keeps ties in input order",
+			input: "id,n\nx,1\ny,3\nz,1\nw,2\n",
+			opts:  Options{Keys: []Key{{Column: "n", Type: Int, Descending: true}}},
+			want:  "id,n\ny,3\nw,2\nx,1\nz,1\n",
+		},
+		{
+			name:  "second key breaks ties",
+			input: "id,a,b\n1,2,y\n2,1,z\n3,2,x\n",
+			opts:  Options{Keys: []Key{{Column: "a", Type: Int}, {Column: "b", Descending: true}}},
+			want:  "id,a,b\n2,1,z\n1,2,y\n3,2,x\n",
+		},
+		{
+			name:  "no header",
+			input: "x,3\ny,1\nz,2\n",
+			opts:  Options{Keys: []Key{{Column: "2", Type: Int}}, NoHeader: true},
+			want:  "y,1\nz,2\nx,3\n",
+		},
+		{
+			name:  "other delimiter",
+			input: "id;n\na;2\nb;1\n",
+			opts:  Options{Keys: []Key{{Column: "n", Type: Int}}, Comma: ';'},
+			want:  "id;n\nb;1\na;2\n",
+		},
+	}
+	for _, test := range tests {
+		var output bytes.Buffer
+		if _, err := Sort(strings.NewReader(test.input), &output, test.opts); err != nil {
+			t.Errorf("%s: %v", test.name, err)
+			continue
+		}
+		if got := output.String(); got != test.want {
+			t.Errorf("%s: got %q, want %q", test.name, got, test.want)
+		}
+	}
+}
+
+// TestSortErrors checks that Sort rejects bad keys and fields.
+func TestSortErrors(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		opts  Options
+	}{
+		{"no keys", "a\n1\n", Options{}},
+		{"unknown column", "a\n1\n", Options{Keys: []Key{{Column: "b"}}}},
+		{"column past the end", "a\n1\n", Options{Keys: []Key{{Column: "2"}}}},
+		{"column name without a header", "1\n", Options{Keys: []Key{{Column: "a"}}, NoHeader: true}},
+		{"short record", "1,2\n3\n", Options{Keys: []Key{{Column: "2"}}, NoHeader: true}},
+		{"bad int", "a\nx\n", Options{Keys: []Key{{Column: "a", Type: Int}}}},
+		{"bad float", "a\n1.5.2\n", Options{Keys: []Key{{Column: "a", Type: Float}}}},
+		{"bad date", "a\n2021-13-01\n", Options{Keys: []Key{{Column: "a", Type: Date}}}},
+		{"unknown algorithm", "a\n1\n", Options{Keys: []Key{{Column: "a"}}, Algorithm: "bogo_sort"}},
+		{"counting sort of strings", "a\n1\n", Options{Keys: []Key{{Column: "a"}}, Algorithm: "counting_sort"}},
+	}
+	for _, test := range tests {
+		if _, err := Sort(strings.NewReader(test.input), io.Discard, test.opts); err == nil {
+			t.Errorf("%s: Sort succeeded", test.name)
+		}
+	}
+}
+
+func TestParseKey(t *testing.T) {
+	for _, test := range []struct {
+		spec string
+		want Key
+	}{
+		{"name", Key{Column: "name"}},
+		{"age:int", Key{Column: "age", Type: Int}},
+		{"age:int:desc", Key{Column: "age", Type: Int, Descending: true}},
+		{" when : date : asc ", Key{Column: "when", Type: Date}},
+		{"3:float", Key{Column: "3", Type: Float}},
+	} {
+		if got, err := ParseKey(test.spec); err != nil || got != test.want {
+			t.Errorf("ParseKey(%q) = %+v, %v; want %+v", test.spec, got, err, test.want)
+		}
+	}
+
+	for _, spec := range []string{"", " ", ":int", "age:number", "age:int:down", "age:int:desc:x"} {
+		if got, err := ParseKey(spec); err == nil {
+			t.Errorf("ParseKey(%q) = %+v, want an error", spec, got)
+		}
+	}
+}
@@ -0,0 +1,165 @@
+package csvsort
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"example.com/m/v2/sorting"
+)
+
+// Type says how the text in a column is compared.
+type Type int
+
+const (
+	// String columns are compared byte by byte.
+	String Type = iota
+	// Int columns hold base 10 integers.
+	Int
+	// Float columns hold floating point numbers. NaN comes before every number.
+	Float
+	// Date columns hold times in the layout given by Options.DateLayout.
+	Date
+)
+
+var type_names = []string{"string", "int", "float", "date"}
+
+// ParseType returns the Type called name.
+func ParseType(name string) (Type, error) {
+	for t, n := range type_names {
+		if n == name {
+			return Type(t), nil
+		}
+	}
+	return 0, fmt.Errorf("csvsort: unknown column type %q (want string, int, float or date)", name)
+}
+
+func (t Type) String() string {
+	if t >= 0 && int(t) < len(type_names) {
+		return type_names[t]
+	}
+	return fmt.Sprintf("Type(%d)", int(t))
+}
+
+// Key is one column to sort by.
+type Key struct {
+	// Column is either a header name or a column number starting at 1.
+	Column string
+	Type   Type
+	// Descending puts the largest values first.
+	Descending bool
+}
+
+// ParseKey parses a key written as column[:type[:asc|desc]], for example
+// "age:int:desc". The type defaults to string and the direction to asc.
+func ParseKey(spec string) (Key, error) {
+	parts := strings.Split(spec, ":")
+	if len(parts) > 3 || strings.TrimSpace(parts[0]) == "" {
+		return Key{}, fmt.Errorf("csvsort: bad key %q (want column[:type[:asc|desc]])", spec)
+	}
+
+	key := Key{Column: strings.TrimSpace(parts[0])}
+	if len(parts) > 1 {
+		t, err := ParseType(strings.TrimSpace(parts[1]))
+		if err != nil {
+			return Key{}, err
+		}
+		key.Type = t
+	}
+	if len(parts) > 2 {
+		switch strings.TrimSpace(parts[2]) {
+		case "asc":
+		case "desc":
+			key.Descending = true
+		default:
+			return Key{}, fmt.Errorf("csvsort: bad direction in key %q (want asc or desc)", spec)
+		}
+	}
+	return key, nil
+}
+
+// ParseKeys parses a comma-separated list of keys. The first key is the
+// most significant.
+func ParseKeys(list string) ([]Key, error) {
+	var keys []Key
+	for _, spec := range strings.Split(list, ",") {
+		if strings.TrimSpace(spec) == "" {
+			continue
+		}
+		key, err := ParseKey(spec)
+		if err != nil {
+			return nil, err
+		}
+		keys = append(keys, key)
+	}
+	if len(keys) == 0 {
+		return nil, fmt.Errorf("csvsort: no sort keys given")
+	}
+	return keys, nil
+}
+
+// value is a field parsed according to its key's type. Only the member
+// that matches the type is set.
+type value struct {
+	i int64
+	f float64
+	s string
+	t time.Time
+}
+
+// parse_value parses text as a value of type t.
+func parse_value(text string, t Type, date_layout string) (value, error) {
+	switch t {
+	case Int:
+		i, err := strconv.ParseInt(strings.TrimSpace(text), 10, 64)
+		return value{i: i}, err
+	case Float:
+		f, err := strconv.ParseFloat(strings.TrimSpace(text), 64)
+		return value{f: f}, err
+	case Date:
+		tm, err := time.Parse(date_layout, strings.TrimSpace(text))
+		return value{t: tm}, err
+	}
+	return value{s: text}, nil
+}
+
+// comparator returns a Comparator for the k-th key of the records.
+func comparator(k int, key Key) sorting.Comparator[record] {
+	var c sorting.Comparator[record]
+	switch key.Type {
+	case Int:
+		c = sorting.By(func(r record) int64 { return r.values[k].i })
+	case Float:
+		c = func(a, b record) int { return compare_floats(a.values[k].f, b.values[k].f) }
+	case Date:
+		c = func(a, b record) int { return a.values[k].t.Compare(b.values[k].t) }
+	default:
+		c = sorting.By(func(r record) string { return r.values[k].s })
+	}
+	if key.Descending {
+		c = c.Reverse()
+	}
+	return c
+}
+
+// compare_floats orders floats numerically with every NaN first.
+func compare_floats(a, b float64) int {
+	a_nan, b_nan := math.IsNaN(a), math.IsNaN(b)
+	switch {
+	case a_nan || b_nan:
+		if a_nan == b_nan {
+			return 0
+		}
+		if a_nan {
+			return -1
+		}
+		return 1
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,104 @@
+// Package datagen makes reproducible test data for the demos, benchmarks
+// and tests in every chapter.
+//
+// Every Generator is created from an explicit seed, so a run that prints its
+// seed can be repeated exactly by passing the same seed again.
+package datagen
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"time"
+)
+
+// Generator makes random data from a seeded source. It embeds *rand.Rand,
+// so the usual methods such as Intn and Float64 are available too.
+//
+// A Generator is not safe for use by several goroutines at once.
+type Generator struct {
+	*rand.Rand
+	seed int64
+}
+
+// New returns a Generator seeded with seed.
+func New(seed int64) *Generator {
+	return &Generator{Rand: rand.New(rand.NewSource(seed)), seed: seed}
+}
+
+// TimeSeed returns a seed taken from the clock, for runs that weren't given one.
+func TimeSeed() int64 {
+	return time.Now().UnixNano()
+}
+
+// Seed returns the seed g was created with.
+func (g *Generator) Seed() int64 {
+	return g.seed
+}
+
+// Uniform returns n ints chosen uniformly from [0, max).
+func (g *Generator) Uniform(n, max int) []int {
+	arr := make([]int, n)
+	for i := range arr {
+		arr[i] = g.Intn(max)
+	}
+	return arr
+}
+
+// UniformRange returns n ints chosen uniformly from [min, max].
+func (g *Generator) UniformRange(n, min, max int) []int {
+	arr := make([]int, n)
+	for i := range arr {
+		arr[i] = min + g.Intn(max-min+1)
+	}
+	return arr
+}
+
+// UniformFloats returns n floats chosen uniformly from [min, max).
+func (g *Generator) UniformFloats(n int, min, max float64) []float64 {
+	arr := make([]float64, n)
+	for i := range arr {
+		arr[i] = min + g.Float64()*(max-min)
+	}
+	return arr
+}
+
+// Normal returns n floats from a normal distribution.
+func (g *Generator) Normal(n int, mean, stddev float64) []float64 {
+	arr := make([]float64, n)
+	for i := range arr {
+		arr[i] = mean + stddev*g.NormFloat64()
+	}
+	return arr
+}
+
+// NormalInts returns n ints from a normal distribution, rounded to the
+// nearest integer.
+func (g *Generator) NormalInts(n int, mean, stddev float64) []int {
+	arr := make([]int, n)
+	for i := range arr {
+		arr[i] = int(math.Round(mean + stddev*g.NormFloat64()))
+	}
+	return arr
+}
+
+// Zipf returns n ints in [0, max] from a Zipf distribution with exponent s.
+// Small values are by far the most common: value k turns up in proportion to
+// 1/(k+1)^s. It panics unless s is greater than 1 and max is not negative.
+func (g *Generator) Zipf(n int, s float64, max int) []int {
+	// rand.NewZipf returns nil for these rather than failing.
+	if !(s > 1) || max < 0 {
+		panic(fmt.Sprintf("datagen: Zipf needs an exponent greater than 1 and a max of at least 0, got %v and %d", s, max))
+	}
+	zipf := rand.NewZipf(g.Rand, s, 1, uint64(max))
+	arr := make([]int, n)
+	for i := range arr {
+		arr[i] = int(zipf.Uint64())
+	}
+	return arr
+}
+
+// Shuffle shuffles arr in place.
+func Shuffle[T any](g *Generator, arr []T) {
+	g.Rand.Shuffle(len(arr), func(i, j int) { arr[i], arr[j] = arr[j], arr[i] })
+}
@@ -0,0 +1,49 @@
+package datagen
+
+import (
+	"math"
+	"testing"
+)
+
+// panics reports whether f panics.
+func panics(f func()) (panicked bool) {
+	defer func() {
+		panicked = recover() != nil
+	}()
+	f()
+	return false
+}
+
+func TestZipf(t *testing.T) {
+	arr := New(1).Zipf(1000, 1.2, 50)
+	for _, v := range arr {
+		if v < 0 || v > 50 {
+			t.Fatalf("Zipf(1000, 1.2, 50) gave %d", v)
+		}
+	}
+
+	for _, s := range []float64{1, 0.5, -2, math.NaN()} {
+		if !panics(func() { New(1).Zipf(10, s, 50) }) {
+			t.Errorf("Zipf with exponent %v didn't panic", s)
+		}
+	}
+	if !panics(func() { New(1).Zipf(10, 1.2, -1) }) {
+		t.Error("Zipf with a negative max didn't panic")
+	}
+}
+
+func TestSawtooth(t *testing.T) {
+	got := Sawtooth(7, 3)
+	want := []int{0, 1, 2, 0, 1, 2, 0}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("Sawtooth(7, 3) = %v, want %v", got, want)
+		}
+	}
+
+	for _, period := range []int{0, -1} {
+		if !panics(func() { Sawtooth(5, period) }) {
+			t.Errorf("Sawtooth with period %d didn't panic", period)
+		}
+	}
+}
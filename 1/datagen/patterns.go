@@ -0,0 +1,125 @@
+package datagen
+
+import (
+	"fmt"
+	"sort"
+)
+
+// Sorted returns 0, 1, ..., n-1.
+func Sorted(n int) []int {
+	arr := make([]int, n)
+	for i := range arr {
+		arr[i] = i
+	}
+	return arr
+}
+
+// Reversed returns n-1, n-2, ..., 0.
+func Reversed(n int) []int {
+	arr := make([]int, n)
+	for i := range arr {
+		arr[i] = n - 1 - i
+	}
+	return arr
+}
+
+// Sawtooth returns n ints that climb from 0 to period-1 and drop back to 0
+// over and over. It panics if period is not positive.
+func Sawtooth(n, period int) []int {
+	if period <= 0 {
+		panic(fmt.Sprintf("datagen: Sawtooth needs a positive period, got %d", period))
+	}
+	arr := make([]int, n)
+	for i := range arr {
+		arr[i] = i % period
+	}
+	return arr
+}
+
+// OrganPipe returns n ints that rise to a peak in the middle and fall again.
+func OrganPipe(n int) []int {
+	arr := make([]int, n)
+	for i := range arr {
+		if i < n/2 {
+			arr[i] = i
+		} else {
+			arr[i] = n - 1 - i
+		}
+	}
+	return arr
+}
+
+// FewUnique returns n ints chosen uniformly from only k values.
+func (g *Generator) FewUnique(n, k int) []int {
+	return g.Uniform(n, k)
+}
+
+// NearlySorted returns 0, 1, ..., n-1 with about fraction·n random pairs
+// swapped.
+func (g *Generator) NearlySorted(n int, fraction float64) []int {
+	arr := Sorted(n)
+	for k := 0; k < int(fraction*float64(n))+1 && n > 1; k++ {
+		i, j := g.Intn(n), g.Intn(n)
+		arr[i], arr[j] = arr[j], arr[i]
+	}
+	return arr
+}
+
+// Mixed returns up to max_len ints with a randomly chosen length and shape:
+// spread out or full of duplicates, positive or negative, and sometimes
+// already sorted or reversed. The randomized tests use it to reach the
+// awkward cases that a uniform slice seldom hits.
+func (g *Generator) Mixed(max_len int) []int {
+	n := g.Intn(max_len + 1)
+	arr := make([]int, n)
+
+	// Pick how widely the values are spread.
+	spread := []int{1, 2, 10, n + 1, 1 << 30}[g.Intn(5)]
+	negative := g.Intn(2) == 0
+	for i := range arr {
+		arr[i] = g.Intn(spread)
+		if negative {
+			arr[i] -= spread / 2
+		}
+	}
+
+	// Sometimes start from a sorted or reversed slice.
+	switch g.Intn(4) {
+	case 0:
+		sort.Ints(arr)
+	case 1:
+		sort.Sort(sort.Reverse(sort.IntSlice(arr)))
+	}
+	return arr
+}
+
+// Distribution is a named shape of int data, for choosing inputs by name.
+type Distribution struct {
+	// Name identifies the distribution in flags and reports.
+	Name string
+	// Make returns n items with this shape.
+	Make func(g *Generator, n int) []int
+}
+
+// Distributions lists the shapes of int data by name.
+var Distributions = []Distribution{
+	{"random", func(g *Generator, n int) []int { return g.Uniform(n, n) }},
+	{"sorted", func(g *Generator, n int) []int { return Sorted(n) }},
+	{"reversed", func(g *Generator, n int) []int { return Reversed(n) }},
+	{"few_unique", func(g *Generator, n int) []int { return g.FewUnique(n, 8) }},
+	{"organ_pipe", func(g *Generator, n int) []int { return OrganPipe(n) }},
+	{"nearly_sorted", func(g *Generator, n int) []int { return g.NearlySorted(n, 0.01) }},
+	{"sawtooth", func(g *Generator, n int) []int { return Sawtooth(n, 64) }},
+	{"normal", func(g *Generator, n int) []int { return g.NormalInts(n, float64(n)/2, float64(n)/8) }},
+	{"zipf", func(g *Generator, n int) []int { return g.Zipf(n, 1.2, n) }},
+}
+
+// DistributionByName returns the distribution called name.
+func DistributionByName(name string) (Distribution, error) {
+	for _, d := range Distributions {
+		if d.Name == name {
+			return d, nil
+		}
+	}
+	return Distribution{}, fmt.Errorf("datagen: unknown distribution %q", name)
+}
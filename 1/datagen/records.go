@@ -0,0 +1,104 @@
+package datagen
+
+import "fmt"
+
+// The letters Strings uses when it isn't given an alphabet.
+const default_alphabet = "abcdefghijklmnopqrstuvwxyz"
+
+// Strings returns n random strings whose lengths are chosen uniformly from
+// [min_len, max_len], made of bytes from alphabet. An empty alphabet means
+// the lowercase letters.
+func (g *Generator) Strings(n, min_len, max_len int, alphabet string) []string {
+	if alphabet == "" {
+		alphabet = default_alphabet
+	}
+	arr := make([]string, n)
+	for i := range arr {
+		b := make([]byte, min_len+g.Intn(max_len-min_len+1))
+		for j := range b {
+			b[j] = alphabet[g.Intn(len(alphabet))]
+		}
+		arr[i] = string(b)
+	}
+	return arr
+}
+
+// Customer is a customer and the number of purchases they have made.
+type Customer struct {
+	ID           string `json:"id"`
+	NumPurchases int    `json:"num_purchases"`
+}
+
+// Customers returns n customers with ids C0, C1, ... and purchase counts
+// chosen uniformly from [0, max_purchases).
+func (g *Generator) Customers(n, max_purchases int) []Customer {
+	customers := make([]Customer, n)
+	for i := range customers {
+		customers[i] = Customer{ID: fmt.Sprintf("C%d", i), NumPurchases: g.Intn(max_purchases)}
+	}
+	return customers
+}
+
+// Employee is an entry in a company phone list.
+type Employee struct {
+	Name  string `json:"name"`
+	Phone string `json:"phone"`
+}
+
+var first_names = []string{
+	"Ann", "Bob", "Cindy", "Dan", "Edwina", "Fred", "Gina", "Herb", "Ida", "Jeb",
+	"Kim", "Lou", "Mia", "Ned", "Olga", "Pat", "Quin", "Rita", "Sam", "Tia",
+}
+
+var last_names = []string{
+	"Archer", "Baker", "Cant", "Deever", "Eager", "Franklin", "Gable", "Henshaw", "Iverson", "Jacobs",
+	"Kelly", "Lopez", "Moss", "Nguyen", "Owens", "Park", "Quincy", "Reyes", "Stone", "Turner",
+}
+
+// There are 800 area codes, 200 to 999, each with 10000 555 numbers.
+const num_phone_numbers = 800 * 10000
+
+// Employees returns n employees with random names, which may repeat, and
+// distinct 555 phone numbers. It panics if n is more than the 8,000,000
+// numbers there are.
+func (g *Generator) Employees(n int) []Employee {
+	if n > num_phone_numbers {
+		panic(fmt.Sprintf("datagen: %d employees need more than the %d phone numbers there are", n, num_phone_numbers))
+	}
+
+	employees := make([]Employee, n)
+	used := make(map[int]bool, n)
+	for i := range employees {
+		name := first_names[g.Intn(len(first_names))] + " " + last_names[g.Intn(len(last_names))]
+
+		// Draw numbers until one is free.
+		number := g.Intn(num_phone_numbers)
+		for used[number] {
+			number = g.Intn(num_phone_numbers)
+		}
+		used[number] = true
+		employees[i] = Employee{Name: name, Phone: fmt.Sprintf("%03d-555-%04d", 200+number/10000, number%10000)}
+	}
+	return employees
+}
+
+// Item is something that can be packed, with a value and a weight.
+type Item struct {
+	ID     int `json:"id"`
+	Value  int `json:"value"`
+	Weight int `json:"weight"`
+}
+
+// Items returns n items with ids 0, 1, ... and values and weights chosen
+// uniformly from [min_value, max_value] and [min_weight, max_weight].
+func (g *Generator) Items(n, min_value, max_value, min_weight, max_weight int) []Item {
+	items := make([]Item, n)
+	for i := range items {
+		items[i] = Item{
+			ID:     i,
+			Value:  min_value + g.Intn(max_value-min_value+1),
+			Weight: min_weight + g.Intn(max_weight-min_weight+1),
+		}
+	}
+	return items
+}
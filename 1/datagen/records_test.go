@@ -0,0 +1,24 @@
+package datagen
+
+import "testing"
+
+func TestEmployeesHaveDistinctPhones(t *testing.T) {
+	// Far more employees than line numbers in one area code.
+	employees := New(1).Employees(50000)
+	seen := make(map[string]bool, len(employees))
+	for _, e := range employees {
+		if seen[e.Phone] {
+			t.Fatalf("phone number %s was given out twice", e.Phone)
+		}
+		seen[e.Phone] = true
+	}
+}
+
+func TestEmployeesRepeat(t *testing.T) {
+	a, b := New(7).Employees(100), New(7).Employees(100)
+	for i := range a {
+		if a[i] != b[i] {
+			t.Fatalf("employee %d differs for the same seed: %v and %v", i, a[i], b[i])
+		}
+	}
+}
@@ -0,0 +1,261 @@
+// Package extsort sorts files of integers that are too large to fit in memory.
+//
+// The input is read in chunks that fit in the memory budget. Each chunk is
+// sorted with quicksort and written to a temporary file called a run, and the
+// runs are then merged with a k-way merge driven by a min-heap.
+package extsort
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"example.com/m/v2/heap"
+	"example.com/m/v2/sorting"
+)
+
+// The memory budget used when Options.MemoryBudget is not set.
+const default_memory_budget = 64 << 20
+
+// Every integer held in memory takes this many bytes.
+const bytes_per_item = 8
+
+// At most this many runs are merged at once, which bounds the number of open files.
+const default_fan_in = 64
+
+// Options control an external sort.
+type Options struct {
+	// Format is the encoding of both the input and the output.
+	Format Format
+	// MemoryBudget is roughly how many bytes of integers to hold in memory
+	// at once. If it is not positive a default of 64 MiB is used.
+	MemoryBudget int
+	// TempDir is where runs are spilled. If it is empty os.TempDir is used.
+	TempDir string
+	// FanIn is the most runs merged in a single pass. If it is less than 2
+	// a default of 64 is used.
+	FanIn int
+}
+
+// SortFile sorts the integers in the file in_path and writes them to out_path.
+// The two must be different files, since creating the output would empty the input.
+func SortFile(in_path, out_path string, opts Options) error {
+	in, err := os.Open(in_path)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+
+	in_info, err := in.Stat()
+	if err != nil {
+		return err
+	}
+	if out_info, err := os.Stat(out_path); err == nil && os.SameFile(in_info, out_info) {
+		return fmt.Errorf("extsort: %s and %s are the same file", in_path, out_path)
+	}
+
+	out, err := os.Create(out_path)
+	if err != nil {
+		return err
+	}
+	if err := Sort(in, out, opts); err != nil {
+		out.Close()
+		return err
+	}
+	return out.Close()
+}
+
+// Sort reads integers from r and writes them to w in ascending order.
+func Sort(r io.Reader, w io.Writer, opts Options) error {
+	if opts.MemoryBudget <= 0 {
+		opts.MemoryBudget = default_memory_budget
+	}
+	if opts.FanIn < 2 {
+		opts.FanIn = default_fan_in
+	}
+
+	input, err := make_reader(r, opts.Format)
+	if err != nil {
+		return err
+	}
+	output, err := make_writer(w, opts.Format)
+	if err != nil {
+		return err
+	}
+
+	chunk_size := opts.MemoryBudget / bytes_per_item
+	if chunk_size < 1 {
+		chunk_size = 1
+	}
+
+	var runs []string
+	defer func() {
+		for _, run := range runs {
+			os.Remove(run)
+		}
+	}()
+
+	chunk := make([]int64, 0, chunk_size)
+	for {
+		chunk, err = read_chunk(input, chunk[:0], chunk_size)
+		if err != nil {
+			return err
+		}
+		sorting.Quicksort(chunk)
+
+		// Everything fitted in memory, so there is nothing to merge.
+		if len(runs) == 0 && len(chunk) < chunk_size {
+			return write_all(output, chunk)
+		}
+		if len(chunk) == 0 {
+			break
+		}
+
+		run, err := write_run(opts.TempDir, chunk)
+		if err != nil {
+			return err
+		}
+		runs = append(runs, run)
+
+		if len(chunk) < chunk_size {
+			break
+		}
+	}
+	chunk = nil
+
+	// Merge the runs in groups until few enough remain to merge them all at once.
+	for len(runs) > opts.FanIn {
+		var merged []string
+		for start := 0; start < len(runs); start += opts.FanIn {
+			end := start + opts.FanIn
+			if end > len(runs) {
+				end = len(runs)
+			}
+			run, err := merge_to_run(opts.TempDir, runs[start:end])
+			if err != nil {
+				// Leave the runs merged so far for the deferred cleanup too.
+				runs = append(runs, merged...)
+				return err
+			}
+			merged = append(merged, run)
+		}
+		for _, run := range runs {
+			os.Remove(run)
+		}
+		runs = merged
+	}
+
+	return merge_runs(runs, output)
+}
+
+// read_chunk appends up to chunk_size integers from input to chunk.
+func read_chunk(input int_reader, chunk []int64, chunk_size int) ([]int64, error) {
+	for len(chunk) < chunk_size {
+		v, err := input.read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		chunk = append(chunk, v)
+	}
+	return chunk, nil
+}
+
+func write_all(output int_writer, values []int64) error {
+	for _, v := range values {
+		if err := output.write(v); err != nil {
+			return err
+		}
+	}
+	return output.flush()
+}
+
+// write_run writes the sorted values to a new temporary file and returns its name.
+// Runs are always stored as Binary64.
+func write_run(dir string, values []int64) (string, error) {
+	file, err := os.CreateTemp(dir, "extsort-run-*")
+	if err != nil {
+		return "", err
+	}
+	output, _ := make_writer(file, Binary64)
+	if err := write_all(output, values); err != nil {
+		file.Close()
+		os.Remove(file.Name())
+		return "", err
+	}
+	if err := file.Close(); err != nil {
+		os.Remove(file.Name())
+		return "", err
+	}
+	return file.Name(), nil
+}
+
+// merge_to_run merges runs into a new temporary run and returns its name.
+func merge_to_run(dir string, runs []string) (string, error) {
+	file, err := os.CreateTemp(dir, "extsort-run-*")
+	if err != nil {
+		return "", err
+	}
+	output, _ := make_writer(file, Binary64)
+	if err := merge_runs(runs, output); err != nil {
+		file.Close()
+		os.Remove(file.Name())
+		return "", err
+	}
+	if err := file.Close(); err != nil {
+		os.Remove(file.Name())
+		return "", err
+	}
+	return file.Name(), nil
+}
+
+// merge_runs writes the merged contents of the sorted runs to output.
+func merge_runs(runs []string, output int_writer) error {
+	// A min-heap of the runs ordered by their next values.
+	h := heap.New(func(a, b run_cursor) bool { return a.head < b.head })
+	for _, run := range runs {
+		file, err := os.Open(run)
+		if err != nil {
+			return err
+		}
+		defer file.Close()
+
+		input, _ := make_reader(file, Binary64)
+		head, err := input.read()
+		if err == io.EOF {
+			continue
+		}
+		if err != nil {
+			return fmt.Errorf("extsort: reading run: %w", err)
+		}
+		h.Push(run_cursor{head: head, input: input})
+	}
+
+	// Repeatedly output the smallest head and advance its run.
+	for h.Len() > 0 {
+		cursor := h.Peek()
+		if err := output.write(cursor.head); err != nil {
+			return err
+		}
+
+		next, err := cursor.input.read()
+		if err == io.EOF {
+			h.Pop()
+			continue
+		}
+		if err != nil {
+			return fmt.Errorf("extsort: reading run: %w", err)
+		}
+		h.ReplaceTop(run_cursor{head: next, input: cursor.input})
+	}
+
+	return output.flush()
+}
+
+// run_cursor is a run being merged and the next value it will produce.
+type run_cursor struct {
+	head  int64
+	input int_reader
+}
@@ -0,0 +1,113 @@
+package extsort
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"testing"
+
+	"example.com/m/v2/datagen"
+)
+
+func encode(values []int64, format Format) []byte {
+	var buf bytes.Buffer
+	output, _ := make_writer(&buf, format)
+	for _, v := range values {
+		output.write(v)
+	}
+	output.flush()
+	return buf.Bytes()
+}
+
+func decode(data []byte, format Format) ([]int64, error) {
+	input, err := make_reader(bytes.NewReader(data), format)
+	if err != nil {
+		return nil, err
+	}
+	var values []int64
+	for {
+		v, err := input.read()
+		if err == io.EOF {
+			return values, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		values = append(values, v)
+	}
+}
+
+// TestSortMultiPass uses a memory budget of a few items and a fan-in of 2 or 3,
+// so that most inputs spill many runs and take several merge passes.
+func TestSortMultiPass(t *testing.T) {
+	dir := t.TempDir()
+	for seed := int64(0); seed < 50; seed++ {
+		r := datagen.New(seed)
+		values := make([]int64, 0, 300)
+		for _, v := range r.Mixed(300) {
+			// Binary32 can't hold anything wider.
+			values = append(values, int64(int32(v)))
+		}
+		want := append([]int64(nil), values...)
+		sort.Slice(want, func(i, j int) bool { return want[i] < want[j] })
+
+		for _, format := range []Format{Text, Binary32, Binary64} {
+			opts := Options{
+				Format:       format,
+				MemoryBudget: (r.Intn(10) + 1) * bytes_per_item,
+				TempDir:      dir,
+				FanIn:        r.Intn(2) + 2,
+			}
+			var out bytes.Buffer
+			if err := Sort(bytes.NewReader(encode(values, format)), &out, opts); err != nil {
+				t.Fatalf("seed %d: sorting %s: %v", seed, format, err)
+			}
+			got, err := decode(out.Bytes(), format)
+			if err != nil {
+				t.Fatalf("seed %d: reading %s output: %v", seed, format, err)
+			}
+			if fmt.Sprint(got) != fmt.Sprint(want) {
+				t.Fatalf("seed %d: sorting %v as %s with %+v gave %v", seed, values, format, opts, got)
+			}
+		}
+	}
+
+	// Every run should have been cleaned up.
+	if runs, _ := filepath.Glob(filepath.Join(dir, "extsort-run-*")); len(runs) != 0 {
+		t.Errorf("%d runs were left behind", len(runs))
+	}
+}
+
+func TestSortFileRefusesToOverwriteInput(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "numbers.txt")
+	data := []byte("3\n1\n2\n")
+	if err := os.WriteFile(path, data, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	link := filepath.Join(dir, "link.txt")
+	if err := os.Link(path, link); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, out_path := range []string{path, filepath.Join(dir, ".", "numbers.txt"), link} {
+		if err := SortFile(path, out_path, Options{}); err == nil {
+			t.Errorf("SortFile(%s, %s) succeeded", path, out_path)
+		}
+		if got, _ := os.ReadFile(path); !bytes.Equal(got, data) {
+			t.Fatalf("SortFile(%s, %s) changed the input to %q", path, out_path, got)
+		}
+	}
+
+	// A different file is fine.
+	out_path := filepath.Join(dir, "sorted.txt")
+	if err := SortFile(path, out_path, Options{}); err != nil {
+		t.Fatal(err)
+	}
+	if got, _ := os.ReadFile(out_path); string(got) != "1\n2\n3\n" {
+		t.Errorf("SortFile wrote %q", got)
+	}
+}
@@ -0,0 +1,162 @@
+package extsort
+
+import (
+	"bufio"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// Format says how the integers in a file are encoded.
+type Format int
+
+const (
+	// Text holds one decimal integer per line. Blank lines are ignored.
+	Text Format = iota
+	// Binary32 holds fixed-width 4-byte little-endian signed integers.
+	Binary32
+	// Binary64 holds fixed-width 8-byte little-endian signed integers.
+	Binary64
+)
+
+// ParseFormat returns the Format called name: "text", "binary32" or "binary64".
+func ParseFormat(name string) (Format, error) {
+	switch strings.ToLower(name) {
+	case "text":
+		return Text, nil
+	case "binary32":
+		return Binary32, nil
+	case "binary64":
+		return Binary64, nil
+	}
+	return 0, fmt.Errorf("extsort: unknown format %q", name)
+}
+
+func (f Format) String() string {
+	switch f {
+	case Text:
+		return "text"
+	case Binary32:
+		return "binary32"
+	case Binary64:
+		return "binary64"
+	}
+	return fmt.Sprintf("Format(%d)", int(f))
+}
+
+// int_reader reads integers one at a time. read returns io.EOF when there are no more.
+type int_reader interface {
+	read() (int64, error)
+}
+
+// int_writer writes integers one at a time. flush must be called when done.
+type int_writer interface {
+	write(v int64) error
+	flush() error
+}
+
+func make_reader(r io.Reader, format Format) (int_reader, error) {
+	switch format {
+	case Text:
+		return &text_reader{scanner: bufio.NewScanner(r)}, nil
+	case Binary32:
+		return &binary_reader{r: bufio.NewReader(r), width: 4}, nil
+	case Binary64:
+		return &binary_reader{r: bufio.NewReader(r), width: 8}, nil
+	}
+	return nil, fmt.Errorf("extsort: unknown format %v", format)
+}
+
+func make_writer(w io.Writer, format Format) (int_writer, error) {
+	switch format {
+	case Text:
+		return &text_writer{w: bufio.NewWriter(w)}, nil
+	case Binary32:
+		return &binary_writer{w: bufio.NewWriter(w), width: 4}, nil
+	case Binary64:
+		return &binary_writer{w: bufio.NewWriter(w), width: 8}, nil
+	}
+	return nil, fmt.Errorf("extsort: unknown format %v", format)
+}
+
+type text_reader struct {
+	scanner *bufio.Scanner
+	line    int
+}
+
+func (r *text_reader) read() (int64, error) {
+	for r.scanner.Scan() {
+		r.line++
+		text := strings.TrimSpace(r.scanner.Text())
+		if text == "" {
+			continue
+		}
+		v, err := strconv.ParseInt(text, 10, 64)
+		if err != nil {
+			return 0, fmt.Errorf("extsort: line %d: %w", r.line, err)
+		}
+		return v, nil
+	}
+	if err := r.scanner.Err(); err != nil {
+		return 0, err
+	}
+	return 0, io.EOF
+}
+
+type text_writer struct {
+	w   *bufio.Writer
+	buf []byte
+}
+
+func (w *text_writer) write(v int64) error {
+	w.buf = strconv.AppendInt(w.buf[:0], v, 10)
+	w.buf = append(w.buf, '\n')
+	_, err := w.w.Write(w.buf)
+	return err
+}
+
+func (w *text_writer) flush() error {
+	return w.w.Flush()
+}
+
+type binary_reader struct {
+	r     *bufio.Reader
+	width int
+	buf   [8]byte
+}
+
+func (r *binary_reader) read() (int64, error) {
+	_, err := io.ReadFull(r.r, r.buf[:r.width])
+	if err == io.ErrUnexpectedEOF {
+		return 0, fmt.Errorf("extsort: file ends partway through a %d-byte integer", r.width)
+	}
+	if err != nil {
+		return 0, err
+	}
+	if r.width == 4 {
+		return int64(int32(binary.LittleEndian.Uint32(r.buf[:4]))), nil
+	}
+	return int64(binary.LittleEndian.Uint64(r.buf[:8])), nil
+}
+
+type binary_writer struct {
+	w     *bufio.Writer
+	width int
+	buf   [8]byte
+}
+
+func (w *binary_writer) write(v int64) error {
+	if w.width == 4 {
+		binary.LittleEndian.PutUint32(w.buf[:4], uint32(int32(v)))
+	} else {
+		binary.LittleEndian.PutUint64(w.buf[:8], uint64(v))
+	}
+	_, err := w.w.Write(w.buf[:w.width])
+	return err
+}
+
+func (w *binary_writer) flush() error {
+	return w.w.Flush()
+}
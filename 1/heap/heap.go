@@ -0,0 +1,149 @@
+// Package heap implements binary heaps: a plain priority queue and an indexed
+// priority queue that supports changing the priority of an item in place.
+//
+// Both are min-heaps under the less function they are given, so the item
+// that sorts first is always on top. Pass a reversed less for a max-heap.
+package heap
+
+import "example.com/m/v2/stats"
+
+// Heap is a binary min-heap stored in a slice, where the children of the
+// item at index i are at 2i+1 and 2i+2.
+type Heap[T any] struct {
+	items []T
+	less  func(a, b T) bool
+	// Stats, if not nil, counts the swaps made by the heap and tells its
+	// Tracer about the comparisons and swaps.
+	Stats *stats.Stats
+}
+
+// New returns an empty heap ordered by less.
+func New[T any](less func(a, b T) bool) *Heap[T] {
+	return &Heap[T]{less: less}
+}
+
+// Heapify returns a heap ordered by less holding items. See Init.
+func Heapify[T any](items []T, less func(a, b T) bool) *Heap[T] {
+	h := New(less)
+	h.Init(items)
+	return h
+}
+
+// Init replaces the contents of the heap with items in O(n) time.
+//
+// The heap works in items itself rather than in a copy. Each Pop swaps the
+// top item to the end of the heap before shrinking it, so after popping
+// every item, items holds them in the reverse of the order they were popped.
+// Heapsort relies on this.
+func (h *Heap[T]) Init(items []T) {
+	h.items = items
+
+	// Sift down every item that has children, starting with the last one.
+	for i := len(items)/2 - 1; i >= 0; i-- {
+		h.down(i)
+	}
+}
+
+// Len returns the number of items in the heap.
+func (h *Heap[T]) Len() int {
+	return len(h.items)
+}
+
+// Push adds item to the heap in O(log n) time.
+func (h *Heap[T]) Push(item T) {
+	h.items = append(h.items, item)
+	h.up(len(h.items) - 1)
+}
+
+// Peek returns the top item without removing it. It panics if the heap is empty.
+func (h *Heap[T]) Peek() T {
+	if len(h.items) == 0 {
+		panic("heap: Peek on an empty heap")
+	}
+	return h.items[0]
+}
+
+// Pop removes and returns the top item in O(log n) time. It panics if the heap is empty.
+func (h *Heap[T]) Pop() T {
+	if len(h.items) == 0 {
+		panic("heap: Pop on an empty heap")
+	}
+
+	// Move the last item to the top and let it sink to its place.
+	top := h.items[0]
+	last := len(h.items) - 1
+	h.swap(0, last)
+	h.items = h.items[:last]
+	h.down(0)
+
+	return top
+}
+
+// ReplaceTop removes the top item, adds item and returns the removed item.
+// It is faster than a Pop followed by a Push. It panics if the heap is empty.
+func (h *Heap[T]) ReplaceTop(item T) T {
+	if len(h.items) == 0 {
+		panic("heap: ReplaceTop on an empty heap")
+	}
+	top := h.items[0]
+	h.items[0] = item
+	h.down(0)
+	return top
+}
+
+// Fix restores the heap order in O(log n) time after the item at index i
+// has been changed. The items can only be reached by index through the slice
+// given to Init, and only while the heap is no longer than it. Fix panics if
+// i is out of range.
+func (h *Heap[T]) Fix(i int) {
+	if i < 0 || i >= len(h.items) {
+		panic("heap: Fix index out of range")
+	}
+	// At most one of these moves the item.
+	h.down(i)
+	h.up(i)
+}
+
+// up moves the item at index i up until its parent is not greater.
+func (h *Heap[T]) up(i int) {
+	for i > 0 {
+		parent := (i - 1) / 2
+		h.Stats.TraceCompare(i, parent)
+		if !h.less(h.items[i], h.items[parent]) {
+			return
+		}
+		h.swap(i, parent)
+		i = parent
+	}
+}
+
+// down moves the item at index i down until neither child is smaller.
+func (h *Heap[T]) down(i int) {
+	n := len(h.items)
+	for {
+		smallest := i
+		left, right := 2*i+1, 2*i+2
+		if left < n {
+			h.Stats.TraceCompare(left, smallest)
+			if h.less(h.items[left], h.items[smallest]) {
+				smallest = left
+			}
+		}
+		if right < n {
+			h.Stats.TraceCompare(right, smallest)
+			if h.less(h.items[right], h.items[smallest]) {
+				smallest = right
+			}
+		}
+		if smallest == i {
+			return
+		}
+		h.swap(i, smallest)
+		i = smallest
+	}
+}
+
+func (h *Heap[T]) swap(i, j int) {
+	h.items[i], h.items[j] = h.items[j], h.items[i]
+	h.Stats.SwapAt(i, j)
+}
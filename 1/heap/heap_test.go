@@ -0,0 +1,127 @@
+package heap
+
+import (
+	"fmt"
+	"sort"
+	"testing"
+
+	"example.com/m/v2/datagen"
+)
+
+func less_int(a, b int) bool { return a < b }
+
+// pop_all pops every item off h.
+func pop_all(h *Heap[int]) []int {
+	var popped []int
+	for h.Len() > 0 {
+		popped = append(popped, h.Pop())
+	}
+	return popped
+}
+
+// TestPushPop pushes random ints, with a Peek and sometimes a ReplaceTop
+// along the way, and checks that they pop in sorted order.
+func TestPushPop(t *testing.T) {
+	for seed := int64(0); seed < 200; seed++ {
+		r := datagen.New(seed)
+		h := New(less_int)
+		var want []int
+		for _, v := range r.Mixed(200) {
+			if h.Len() > 0 && r.Intn(4) == 0 {
+				// The replaced top must be the smallest item so far.
+				sort.Ints(want)
+				if top := h.ReplaceTop(v); top != want[0] {
+					t.Fatalf("seed %d: ReplaceTop returned %d, want %d", seed, top, want[0])
+				}
+				want[0] = v
+			} else {
+				h.Push(v)
+				want = append(want, v)
+			}
+			if h.Len() != len(want) {
+				t.Fatalf("seed %d: Len() = %d, want %d", seed, h.Len(), len(want))
+			}
+		}
+		sort.Ints(want)
+
+		if len(want) > 0 && h.Peek() != want[0] {
+			t.Fatalf("seed %d: Peek() = %d, want %d", seed, h.Peek(), want[0])
+		}
+		if got := pop_all(h); fmt.Sprint(got) != fmt.Sprint(want) {
+			t.Fatalf("seed %d: popped %v, want %v", seed, got, want)
+		}
+	}
+}
+
+// TestHeapifyInPlace checks that popping every item of a heap made by
+// Heapify leaves its slice in reverse order, which heapsort relies on.
+func TestHeapifyInPlace(t *testing.T) {
+	for seed := int64(0); seed < 200; seed++ {
+		items := datagen.New(seed).Mixed(200)
+		want := append([]int(nil), items...)
+		sort.Sort(sort.Reverse(sort.IntSlice(want)))
+
+		pop_all(Heapify(items, less_int))
+		if fmt.Sprint(items) != fmt.Sprint(want) {
+			t.Fatalf("seed %d: the items ended up as %v, want %v", seed, items, want)
+		}
+	}
+}
+
+// TestFix changes random items in the slice behind a heap, calling Fix after
+// each change, and checks that they still pop in sorted order.
+func TestFix(t *testing.T) {
+	for seed := int64(0); seed < 200; seed++ {
+		r := datagen.New(seed)
+		items := r.Mixed(200)
+		if len(items) == 0 {
+			continue
+		}
+		h := Heapify(items, less_int)
+		for k := 0; k < len(items); k++ {
+			i := r.Intn(len(items))
+			items[i] += r.Intn(201) - 100
+			h.Fix(i)
+		}
+		want := append([]int(nil), items...)
+		sort.Ints(want)
+
+		if got := pop_all(h); fmt.Sprint(got) != fmt.Sprint(want) {
+			t.Fatalf("seed %d: popped %v, want %v", seed, got, want)
+		}
+	}
+}
+
+// TestEmpty checks that the operations that need an item panic on an empty
+// heap, as documented.
+func TestEmpty(t *testing.T) {
+	for name, f := range map[string]func(h *Heap[int]){
+		"Pop":        func(h *Heap[int]) { h.Pop() },
+		"Peek":       func(h *Heap[int]) { h.Peek() },
+		"ReplaceTop": func(h *Heap[int]) { h.ReplaceTop(1) },
+		"Fix":        func(h *Heap[int]) { h.Fix(0) },
+	} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("%s on an empty heap didn't panic", name)
+				}
+			}()
+			h := New(less_int)
+			f(h)
+		}()
+	}
+
+	// A heap emptied by popping panics too.
+	h := New(less_int)
+	h.Push(1)
+	if got := h.Pop(); got != 1 {
+		t.Fatalf("Pop() = %d, want 1", got)
+	}
+	defer func() {
+		if recover() == nil {
+			t.Error("Pop on an emptied heap didn't panic")
+		}
+	}()
+	h.Pop()
+}
@@ -0,0 +1,165 @@
+package heap
+
+// IndexedHeap is a binary min-heap of items identified by integer ids in
+// [0, n). Because it knows where every id sits in the heap it can change
+// an item's priority in O(log n) time, which algorithms such as Dijkstra's
+// shortest paths need.
+type IndexedHeap[P any] struct {
+	// ids[i] is the id at heap position i.
+	ids []int
+	// position[id] is the id's heap position, or -1 if it is not in the heap.
+	position []int
+	// priority[id] is the id's current priority.
+	priority []P
+	less     func(a, b P) bool
+}
+
+// NewIndexed returns an empty indexed heap for ids in [0, n) whose
+// priorities are ordered by less.
+func NewIndexed[P any](n int, less func(a, b P) bool) *IndexedHeap[P] {
+	position := make([]int, n)
+	for i := range position {
+		position[i] = -1
+	}
+	return &IndexedHeap[P]{
+		position: position,
+		priority: make([]P, n),
+		less:     less,
+	}
+}
+
+// Len returns the number of ids in the heap.
+func (h *IndexedHeap[P]) Len() int {
+	return len(h.ids)
+}
+
+// Contains reports whether id is in the heap.
+func (h *IndexedHeap[P]) Contains(id int) bool {
+	return h.position[id] >= 0
+}
+
+// Priority returns the priority of id, which must be in the heap.
+func (h *IndexedHeap[P]) Priority(id int) P {
+	if !h.Contains(id) {
+		panic("heap: Priority of an id that is not in the heap")
+	}
+	return h.priority[id]
+}
+
+// Push adds id with the given priority. It panics if id is already in the heap.
+func (h *IndexedHeap[P]) Push(id int, priority P) {
+	if h.Contains(id) {
+		panic("heap: Push of an id that is already in the heap")
+	}
+	h.priority[id] = priority
+	h.position[id] = len(h.ids)
+	h.ids = append(h.ids, id)
+	h.up(len(h.ids) - 1)
+}
+
+// Peek returns the id with the smallest priority, and that priority,
+// without removing it. It panics if the heap is empty.
+func (h *IndexedHeap[P]) Peek() (id int, priority P) {
+	if len(h.ids) == 0 {
+		panic("heap: Peek on an empty heap")
+	}
+	return h.ids[0], h.priority[h.ids[0]]
+}
+
+// Pop removes the id with the smallest priority and returns it with its
+// priority. It panics if the heap is empty.
+func (h *IndexedHeap[P]) Pop() (id int, priority P) {
+	if len(h.ids) == 0 {
+		panic("heap: Pop on an empty heap")
+	}
+	id = h.ids[0]
+
+	last := len(h.ids) - 1
+	h.swap(0, last)
+	h.ids = h.ids[:last]
+	h.position[id] = -1
+	h.down(0)
+
+	return id, h.priority[id]
+}
+
+// DecreaseKey lowers the priority of id, which must be in the heap. It
+// panics if priority would sort after the id's current priority.
+func (h *IndexedHeap[P]) DecreaseKey(id int, priority P) {
+	if !h.Contains(id) {
+		panic("heap: DecreaseKey of an id that is not in the heap")
+	}
+	if h.less(h.priority[id], priority) {
+		panic("heap: DecreaseKey would increase the priority")
+	}
+	h.priority[id] = priority
+	h.up(h.position[id])
+}
+
+// Update changes the priority of id, which must be in the heap, in either direction.
+func (h *IndexedHeap[P]) Update(id int, priority P) {
+	if !h.Contains(id) {
+		panic("heap: Update of an id that is not in the heap")
+	}
+	h.priority[id] = priority
+	h.up(h.position[id])
+	h.down(h.position[id])
+}
+
+// Remove takes id, which must be in the heap, out of the heap.
+func (h *IndexedHeap[P]) Remove(id int) {
+	if !h.Contains(id) {
+		panic("heap: Remove of an id that is not in the heap")
+	}
+	i := h.position[id]
+	last := len(h.ids) - 1
+	h.swap(i, last)
+	h.ids = h.ids[:last]
+	h.position[id] = -1
+
+	// The item moved into the hole may need to go either way.
+	if i < last {
+		h.up(i)
+		h.down(i)
+	}
+}
+
+func (h *IndexedHeap[P]) less_at(i, j int) bool {
+	return h.less(h.priority[h.ids[i]], h.priority[h.ids[j]])
+}
+
+func (h *IndexedHeap[P]) swap(i, j int) {
+	h.ids[i], h.ids[j] = h.ids[j], h.ids[i]
+	h.position[h.ids[i]] = i
+	h.position[h.ids[j]] = j
+}
+
+func (h *IndexedHeap[P]) up(i int) {
+	for i > 0 {
+		parent := (i - 1) / 2
+		if !h.less_at(i, parent) {
+			return
+		}
+		h.swap(i, parent)
+		i = parent
+	}
+}
+
+func (h *IndexedHeap[P]) down(i int) {
+	n := len(h.ids)
+	for {
+		smallest := i
+		left, right := 2*i+1, 2*i+2
+		if left < n && h.less_at(left, smallest) {
+			smallest = left
+		}
+		if right < n && h.less_at(right, smallest) {
+			smallest = right
+		}
+		if smallest == i {
+			return
+		}
+		h.swap(i, smallest)
+		i = smallest
+	}
+}
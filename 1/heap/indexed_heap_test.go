@@ -0,0 +1,57 @@
+package heap
+
+import (
+	"testing"
+
+	"example.com/m/v2/datagen"
+)
+
+// TestIndexedHeap applies random operations to an indexed heap and to a
+// plain map of priorities and checks that they agree.
+func TestIndexedHeap(t *testing.T) {
+	for seed := int64(0); seed < 200; seed++ {
+		r := datagen.New(seed)
+		n := r.Intn(200) + 1
+		h := NewIndexed(n, func(a, b int) bool { return a < b })
+		want := map[int]int{}
+
+		for step := 0; step < 3*n; step++ {
+			id := r.Intn(n)
+			priority := r.Intn(100)
+			_, present := want[id]
+
+			switch op := r.Intn(4); {
+			case op == 0 && !present:
+				h.Push(id, priority)
+				want[id] = priority
+			case op == 1 && present && priority <= want[id]:
+				h.DecreaseKey(id, priority)
+				want[id] = priority
+			case op == 2 && present:
+				h.Update(id, priority)
+				want[id] = priority
+			case op == 3 && present:
+				h.Remove(id)
+				delete(want, id)
+			}
+
+			if h.Len() != len(want) {
+				t.Fatalf("seed %d: heap holds %d ids, want %d", seed, h.Len(), len(want))
+			}
+		}
+
+		// Popping must produce the priorities in order.
+		last := -1
+		for h.Len() > 0 {
+			id, priority := h.Pop()
+			if priority != want[id] || priority < last {
+				t.Fatalf("seed %d: popped id %d with priority %d after %d, want priority %d", seed, id, priority, last, want[id])
+			}
+			delete(want, id)
+			last = priority
+		}
+		if len(want) != 0 {
+			t.Fatalf("seed %d: %d ids were never popped", seed, len(want))
+		}
+	}
+}
@@ -0,0 +1,12 @@
+// Package branchless holds the helpers that let the sorts and searches avoid
+// data-dependent branches, which the processor can't predict.
+package branchless
+
+// B2i returns 1 if b is true and 0 otherwise. The compiler inlines it and
+// turns it into a flag-setting instruction rather than a branch.
+func B2i(b bool) int {
+	if b {
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,70 @@
+// Package testutil holds the helpers shared by the randomized tests of the
+// sorting and searching packages.
+package testutil
+
+import (
+	"fmt"
+	"testing"
+
+	"example.com/m/v2/datagen"
+)
+
+// The randomized tests try NumRounds inputs of at most MaxLen items.
+const (
+	NumRounds = 200
+	MaxLen    = 200
+)
+
+// RunRounds runs check on NumRounds random inputs, seeding round i with i,
+// and stops at the first failure, reporting its seed so it can be replayed.
+// With -short it runs a tenth as many rounds.
+func RunRounds(t *testing.T, check func(r *datagen.Generator, max_len int) error) {
+	t.Helper()
+	rounds := NumRounds
+	if testing.Short() {
+		rounds /= 10
+	}
+	for seed := int64(0); seed < int64(rounds); seed++ {
+		if err := check(datagen.New(seed), MaxLen); err != nil {
+			t.Fatalf("seed %d: %v", seed, err)
+		}
+	}
+}
+
+// Describe shortens a slice for an error message.
+func Describe[T any](arr []T) string {
+	if len(arr) > 20 {
+		return fmt.Sprintf("%v... (%d items)", arr[:20], len(arr))
+	}
+	return fmt.Sprint(arr)
+}
+
+// Equal reports whether a and b hold the same items in the same order.
+func Equal[T comparable](a, b []T) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// RandomText returns a string of at most max_len bytes over a small
+// alphabet, so that it is full of repeats, or over all byte values.
+func RandomText(r *datagen.Generator, max_len int) string {
+	alphabet := "ab"
+	switch r.Intn(3) {
+	case 0:
+		alphabet = "acgt"
+	case 1:
+		b := make([]byte, 256)
+		for i := range b {
+			b[i] = byte(i)
+		}
+		alphabet = string(b)
+	}
+	return r.Strings(1, 0, max_len, alphabet)[0]
+}
@@ -0,0 +1,202 @@
+package search
+
+import (
+	"example.com/m/v2/sorting"
+	"example.com/m/v2/stats"
+)
+
+// BinarySearch returns the index of an item equal to target in the sorted
+// slice arr, or -1 if there is none. If several items match, any of them may
+// be returned; use FirstOccurrence or LastOccurrence to pick one.
+func BinarySearch[T sorting.Ordered](arr []T, target T) (index, num_tests int) {
+	return binary_search(arr, target, nil)
+}
+
+// BinarySearchStats is like BinarySearch but records the items it examines
+// in s as probes.
+func BinarySearchStats[T sorting.Ordered](arr []T, target T, s *stats.Stats) (index, num_tests int) {
+	return binary_search(arr, target, s)
+}
+
+func binary_search[T sorting.Ordered](arr []T, target T, s *stats.Stats) (index, num_tests int) {
+	examined_items := 0
+
+	l := 0
+	r := len(arr) - 1
+
+	for l <= r {
+		// We are examining arr[m] here.
+		examined_items += 1
+		s.Probe(1)
+
+		m := l + (r-l)/2
+		if arr[m] < target {
+			l = m + 1
+		} else if arr[m] > target {
+			r = m - 1
+		} else {
+			return m, examined_items
+		}
+	}
+
+	// Unsuccessful.
+	return -1, examined_items
+}
+
+// Search returns the smallest x in [lo, hi) for which pred(x) is true, or hi if
+// there is none. pred must be monotonic: once it is true it stays true.
+//
+// This is "binary search on the answer": x need not index an array, so it can
+// find, for example, the smallest capacity that satisfies some constraint.
+func Search(lo, hi int, pred func(x int) bool) (index, num_tests int) {
+	return search(lo, hi, pred, nil)
+}
+
+// SearchStats is like Search but records each call to pred in s as a probe.
+func SearchStats(lo, hi int, pred func(x int) bool, s *stats.Stats) (index, num_tests int) {
+	return search(lo, hi, pred, s)
+}
+
+func search(lo, hi int, pred func(x int) bool, s *stats.Stats) (index, num_tests int) {
+	examined_items := 0
+
+	// Invariant: pred is false before lo and true from hi onwards.
+	for lo < hi {
+		examined_items += 1
+		s.Probe(1)
+
+		m := lo + (hi-lo)/2
+		if pred(m) {
+			hi = m
+		} else {
+			lo = m + 1
+		}
+	}
+
+	return lo, examined_items
+}
+
+// LowerBound returns the index of the first item in the sorted slice arr that
+// is not less than target, or len(arr) if there is none. This is where target
+// would be inserted to keep arr sorted.
+func LowerBound[T sorting.Ordered](arr []T, target T) (index, num_tests int) {
+	return lower_bound(arr, target, nil)
+}
+
+// LowerBoundStats is like LowerBound but records the items it examines in s
+// as probes.
+func LowerBoundStats[T sorting.Ordered](arr []T, target T, s *stats.Stats) (index, num_tests int) {
+	return lower_bound(arr, target, s)
+}
+
+func lower_bound[T sorting.Ordered](arr []T, target T, s *stats.Stats) (index, num_tests int) {
+	return search(0, len(arr), func(i int) bool { return arr[i] >= target }, s)
+}
+
+// UpperBound returns the index of the first item in the sorted slice arr that
+// is greater than target, or len(arr) if there is none.
+func UpperBound[T sorting.Ordered](arr []T, target T) (index, num_tests int) {
+	return upper_bound(arr, target, nil)
+}
+
+// UpperBoundStats is like UpperBound but records the items it examines in s
+// as probes.
+func UpperBoundStats[T sorting.Ordered](arr []T, target T, s *stats.Stats) (index, num_tests int) {
+	return upper_bound(arr, target, s)
+}
+
+func upper_bound[T sorting.Ordered](arr []T, target T, s *stats.Stats) (index, num_tests int) {
+	return search(0, len(arr), func(i int) bool { return arr[i] > target }, s)
+}
+
+// EqualRange returns the range arr[lo:hi] of items equal to target in the
+// sorted slice arr. If there are none, lo == hi is where target would go.
+func EqualRange[T sorting.Ordered](arr []T, target T) (lo, hi, num_tests int) {
+	return equal_range(arr, target, nil)
+}
+
+// EqualRangeStats is like EqualRange but records the items it examines in s
+// as probes.
+func EqualRangeStats[T sorting.Ordered](arr []T, target T, s *stats.Stats) (lo, hi, num_tests int) {
+	return equal_range(arr, target, s)
+}
+
+func equal_range[T sorting.Ordered](arr []T, target T, s *stats.Stats) (lo, hi, num_tests int) {
+	lo, lower_tests := lower_bound(arr, target, s)
+
+	// The upper bound can't come before the lower bound.
+	hi, upper_tests := search(lo, len(arr), func(i int) bool { return arr[i] > target }, s)
+
+	return lo, hi, lower_tests + upper_tests
+}
+
+// FirstOccurrence returns the index of the first item equal to target in the
+// sorted slice arr, or -1 if there is none.
+func FirstOccurrence[T sorting.Ordered](arr []T, target T) (index, num_tests int) {
+	return first_occurrence(arr, target, nil)
+}
+
+// FirstOccurrenceStats is like FirstOccurrence but records the items it
+// examines in s as probes.
+func FirstOccurrenceStats[T sorting.Ordered](arr []T, target T, s *stats.Stats) (index, num_tests int) {
+	return first_occurrence(arr, target, s)
+}
+
+func first_occurrence[T sorting.Ordered](arr []T, target T, s *stats.Stats) (index, num_tests int) {
+	i, num_tests := lower_bound(arr, target, s)
+	if i < len(arr) && arr[i] == target {
+		return i, num_tests
+	}
+	return -1, num_tests
+}
+
+// LastOccurrence returns the index of the last item equal to target in the
+// sorted slice arr, or -1 if there is none.
+func LastOccurrence[T sorting.Ordered](arr []T, target T) (index, num_tests int) {
+	return last_occurrence(arr, target, nil)
+}
+
+// LastOccurrenceStats is like LastOccurrence but records the items it
+// examines in s as probes.
+func LastOccurrenceStats[T sorting.Ordered](arr []T, target T, s *stats.Stats) (index, num_tests int) {
+	return last_occurrence(arr, target, s)
+}
+
+func last_occurrence[T sorting.Ordered](arr []T, target T, s *stats.Stats) (index, num_tests int) {
+	i, num_tests := upper_bound(arr, target, s)
+	if i > 0 && arr[i-1] == target {
+		return i - 1, num_tests
+	}
+	return -1, num_tests
+}
+
+// Nearest returns the index of the item in the sorted slice arr whose value is
+// closest to target, or -1 if arr is empty. Ties go to the smaller item.
+func Nearest[T Number](arr []T, target T) (index, num_tests int) {
+	return nearest(arr, target, nil)
+}
+
+// NearestStats is like Nearest but records the items it examines in s as
+// probes.
+func NearestStats[T Number](arr []T, target T, s *stats.Stats) (index, num_tests int) {
+	return nearest(arr, target, s)
+}
+
+func nearest[T Number](arr []T, target T, s *stats.Stats) (index, num_tests int) {
+	if len(arr) == 0 {
+		return -1, 0
+	}
+
+	// The nearest item is either the first one >= target or the one before it.
+	i, num_tests := lower_bound(arr, target, s)
+	if i == 0 {
+		return 0, num_tests
+	}
+	if i == len(arr) {
+		return i - 1, num_tests
+	}
+	if arr[i]-target < target-arr[i-1] {
+		return i, num_tests
+	}
+	return i - 1, num_tests
+}
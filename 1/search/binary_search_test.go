@@ -0,0 +1,166 @@
+package search
+
+import (
+	"fmt"
+	"sort"
+	"testing"
+
+	"example.com/m/v2/datagen"
+	"example.com/m/v2/internal/testutil"
+	"example.com/m/v2/stats"
+)
+
+// sorted_ints_and_target returns a sorted random slice and a value to look for,
+// which is as likely to be missing as present.
+func sorted_ints_and_target(r *datagen.Generator, max_len int) ([]int, int) {
+	arr := r.Mixed(max_len)
+	sort.Ints(arr)
+
+	target := r.Intn(21) - 10
+	if len(arr) > 0 && r.Intn(2) == 0 {
+		target = arr[r.Intn(len(arr))]
+	}
+	return arr, target
+}
+
+func distance(a, b int) int {
+	if a < b {
+		return b - a
+	}
+	return a - b
+}
+
+// check_exact_searches checks that every exact-match search agrees with
+// linear search about whether and where the target is.
+func check_exact_searches(arr []int, target int) error {
+	first, _ := LinearSearch(arr, target)
+
+	exact := []struct {
+		name   string
+		search func(arr []int, target int) (index, num_tests int)
+	}{
+		{"BinarySearch", BinarySearch[int]},
+		{"InterpolationSearch", InterpolationSearch[int]},
+		{"ExponentialSearch", ExponentialSearch[int]},
+		{"FirstOccurrence", FirstOccurrence[int]},
+		{"LastOccurrence", LastOccurrence[int]},
+	}
+	for _, s := range exact {
+		index, num_tests := s.search(arr, target)
+		if (index == -1) != (first == -1) {
+			return fmt.Errorf("%s(%s, %d) = %d but linear search found %d", s.name, testutil.Describe(arr), target, index, first)
+		}
+		if index != -1 && arr[index] != target {
+			return fmt.Errorf("%s(%s, %d) = %d, which holds %d", s.name, testutil.Describe(arr), target, index, arr[index])
+		}
+		if num_tests < 0 || num_tests > len(arr)+1 {
+			return fmt.Errorf("%s(%s, %d) reported %d tests", s.name, testutil.Describe(arr), target, num_tests)
+		}
+	}
+
+	// Linear search finds the first occurrence.
+	if index, _ := FirstOccurrence(arr, target); index != first {
+		return fmt.Errorf("FirstOccurrence(%s, %d) = %d, want %d", testutil.Describe(arr), target, index, first)
+	}
+	return nil
+}
+
+func TestExactSearchesAgreeWithLinearSearch(t *testing.T) {
+	testutil.RunRounds(t, func(r *datagen.Generator, max_len int) error {
+		return check_exact_searches(sorted_ints_and_target(r, max_len))
+	})
+}
+
+// TestBounds compares the bound searches with sort.SearchInts.
+func TestBounds(t *testing.T) {
+	testutil.RunRounds(t, func(r *datagen.Generator, max_len int) error {
+		arr, target := sorted_ints_and_target(r, max_len)
+		want_lo := sort.SearchInts(arr, target)
+		want_hi := sort.SearchInts(arr, target+1)
+
+		if lo, _ := LowerBound(arr, target); lo != want_lo {
+			return fmt.Errorf("LowerBound(%s, %d) = %d, want %d", testutil.Describe(arr), target, lo, want_lo)
+		}
+		if hi, _ := UpperBound(arr, target); hi != want_hi {
+			return fmt.Errorf("UpperBound(%s, %d) = %d, want %d", testutil.Describe(arr), target, hi, want_hi)
+		}
+		if lo, hi, _ := EqualRange(arr, target); lo != want_lo || hi != want_hi {
+			return fmt.Errorf("EqualRange(%s, %d) = [%d, %d), want [%d, %d)", testutil.Describe(arr), target, lo, hi, want_lo, want_hi)
+		}
+
+		// No other item can be strictly closer than the nearest one.
+		index, _ := Nearest(arr, target)
+		if len(arr) == 0 {
+			if index != -1 {
+				return fmt.Errorf("Nearest(%s, %d) = %d, want -1", testutil.Describe(arr), target, index)
+			}
+			return nil
+		}
+		best := distance(arr[index], target)
+		for _, v := range arr {
+			if distance(v, target) < best {
+				return fmt.Errorf("Nearest(%s, %d) = %d but %d is closer", testutil.Describe(arr), target, arr[index], v)
+			}
+		}
+		return nil
+	})
+}
+
+// TestSearchStats checks that the Stats variants of the searches give the
+// same answers and record one probe for each item they examine.
+func TestSearchStats(t *testing.T) {
+	searches := []struct {
+		name   string
+		plain  func(arr []int, target int) (index, num_tests int)
+		counts func(arr []int, target int, s *stats.Stats) (index, num_tests int)
+	}{
+		{"LinearSearch", LinearSearch[int], LinearSearchStats[int]},
+		{"BinarySearch", BinarySearch[int], BinarySearchStats[int]},
+		{"LowerBound", LowerBound[int], LowerBoundStats[int]},
+		{"UpperBound", UpperBound[int], UpperBoundStats[int]},
+		{"FirstOccurrence", FirstOccurrence[int], FirstOccurrenceStats[int]},
+		{"LastOccurrence", LastOccurrence[int], LastOccurrenceStats[int]},
+		{"Nearest", Nearest[int], NearestStats[int]},
+		{"ExponentialSearch", ExponentialSearch[int], ExponentialSearchStats[int]},
+		{"InterpolationSearch", InterpolationSearch[int], InterpolationSearchStats[int]},
+		{"EqualRange", func(arr []int, target int) (int, int) {
+			lo, _, num_tests := EqualRange(arr, target)
+			return lo, num_tests
+		}, func(arr []int, target int, s *stats.Stats) (int, int) {
+			lo, _, num_tests := EqualRangeStats(arr, target, s)
+			return lo, num_tests
+		}},
+	}
+	testutil.RunRounds(t, func(r *datagen.Generator, max_len int) error {
+		arr, target := sorted_ints_and_target(r, max_len)
+		for _, search := range searches {
+			want, want_tests := search.plain(arr, target)
+			var s stats.Stats
+			got, num_tests := search.counts(arr, target, &s)
+			if got != want || num_tests != want_tests || s.Probes != int64(num_tests) {
+				return fmt.Errorf("%sStats(%s, %d) = %d, %d tests, %d probes; want %d, %d tests and as many probes",
+					search.name, testutil.Describe(arr), target, got, num_tests, s.Probes, want, want_tests)
+			}
+		}
+		return nil
+	})
+}
+
+// FuzzBinarySearch sorts the fuzzer's bytes, one small signed int per byte,
+// and checks the exact-match searches against linear search.
+func FuzzBinarySearch(f *testing.F) {
+	f.Add([]byte{}, int8(0))
+	f.Add([]byte{1, 1, 1}, int8(1))
+	f.Add([]byte{255, 0, 128, 127, 0, 255}, int8(-1))
+	f.Add([]byte("binary search"), int8('s'))
+	f.Fuzz(func(t *testing.T, data []byte, target int8) {
+		arr := make([]int, len(data))
+		for i, b := range data {
+			arr[i] = int(int8(b))
+		}
+		sort.Ints(arr)
+		if err := check_exact_searches(arr, int(target)); err != nil {
+			t.Fatal(err)
+		}
+	})
+}
@@ -0,0 +1,117 @@
+package search
+
+import (
+	"example.com/m/v2/sorting"
+	"example.com/m/v2/stats"
+)
+
+// Number is satisfied by the integer and floating point types.
+type Number interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64 |
+		~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 | ~uintptr |
+		~float32 | ~float64
+}
+
+// ExponentialSearch returns the index of the first item equal to target in
+// the sorted slice arr, or -1 if there is none.
+//
+// It gallops through arr[1], arr[3], arr[7], ... until it passes target and
+// then binary searches the last gap, so it needs O(log i) tests to find an
+// item at index i. That makes it a good choice when target is likely to be
+// near the start.
+func ExponentialSearch[T sorting.Ordered](arr []T, target T) (index, num_tests int) {
+	return exponential_search(arr, target, nil)
+}
+
+// ExponentialSearchStats is like ExponentialSearch but records the items it
+// examines in s as probes.
+func ExponentialSearchStats[T sorting.Ordered](arr []T, target T, s *stats.Stats) (index, num_tests int) {
+	return exponential_search(arr, target, s)
+}
+
+func exponential_search[T sorting.Ordered](arr []T, target T, s *stats.Stats) (index, num_tests int) {
+	examined_items := 0
+
+	// Find a bound with arr[bound] >= target, doubling the step each time.
+	lo, bound := 0, 0
+	for bound < len(arr) {
+		examined_items += 1
+		s.Probe(1)
+		if arr[bound] >= target {
+			break
+		}
+		lo = bound + 1
+		bound = 2*bound + 1
+	}
+	hi := bound + 1
+	if hi > len(arr) {
+		hi = len(arr)
+	}
+
+	// target, if present, starts somewhere in arr[lo:hi].
+	i, tests := search(lo, hi, func(i int) bool { return arr[i] >= target }, s)
+	examined_items += tests
+	if i < len(arr) && arr[i] == target {
+		return i, examined_items
+	}
+	return -1, examined_items
+}
+
+// InterpolationSearch returns the index of an item equal to target in the
+// sorted slice arr, or -1 if there is none.
+//
+// Instead of probing the middle of the range it guesses where target should be
+// from the values at the ends of the range. For evenly spread values that
+// takes O(log log n) tests, but it can take O(n) when the values are skewed.
+func InterpolationSearch[T Number](arr []T, target T) (index, num_tests int) {
+	return interpolation_search(arr, target, nil)
+}
+
+// InterpolationSearchStats is like InterpolationSearch but records the items
+// it examines in s as probes.
+func InterpolationSearchStats[T Number](arr []T, target T, s *stats.Stats) (index, num_tests int) {
+	return interpolation_search(arr, target, s)
+}
+
+func interpolation_search[T Number](arr []T, target T, s *stats.Stats) (index, num_tests int) {
+	examined_items := 0
+
+	l := 0
+	r := len(arr) - 1
+
+	for l <= r && target >= arr[l] && target <= arr[r] {
+		// All of the remaining items are equal.
+		if arr[l] == arr[r] {
+			examined_items += 1
+			s.Probe(1)
+			if arr[l] == target {
+				return l, examined_items
+			}
+			return -1, examined_items
+		}
+
+		// Estimate target's position from where it falls between arr[l] and arr[r].
+		// Large integers can round to the same float, making the span 0 and
+		// the fraction NaN, or push the fraction just outside [0, 1], so in
+		// those cases probe the middle instead, which keeps m in [l, r].
+		span := float64(arr[r]) - float64(arr[l])
+		fraction := (float64(target) - float64(arr[l])) / span
+		m := l + (r-l)/2
+		if span > 0 && fraction >= 0 && fraction <= 1 {
+			m = l + int(fraction*float64(r-l))
+		}
+
+		examined_items += 1
+		s.Probe(1)
+		if arr[m] < target {
+			l = m + 1
+		} else if arr[m] > target {
+			r = m - 1
+		} else {
+			return m, examined_items
+		}
+	}
+
+	// Unsuccessful.
+	return -1, examined_items
+}
@@ -0,0 +1,26 @@
+package search
+
+import "testing"
+
+// TestInterpolationSearchLargeValues searches values too close together to
+// tell apart as floats, where the estimated position is meaningless.
+func TestInterpolationSearchLargeValues(t *testing.T) {
+	arr := []int64{1 << 62, 1<<62 + 1}
+	for i, target := range arr {
+		if index, _ := InterpolationSearch(arr, target); index != i {
+			t.Errorf("InterpolationSearch(%v, %d) = %d, want %d", arr, target, index, i)
+		}
+	}
+
+	arr = []int64{1<<62 + 1, 1<<62 + 2, 1<<62 + 3, 1<<62 + 5, 1<<62 + 8}
+	for _, target := range []int64{1 << 62, 1<<62 + 4, 1<<62 + 9} {
+		if index, _ := InterpolationSearch(arr, target); index != -1 {
+			t.Errorf("InterpolationSearch(%v, %d) = %d, want -1", arr, target, index)
+		}
+	}
+	for i, target := range arr {
+		if index, _ := InterpolationSearch(arr, target); index != i {
+			t.Errorf("InterpolationSearch(%v, %d) = %d, want %d", arr, target, index, i)
+		}
+	}
+}
@@ -0,0 +1,104 @@
+package search
+
+import (
+	"math/bits"
+
+	"example.com/m/v2/internal/branchless"
+	"example.com/m/v2/sorting"
+	"example.com/m/v2/stats"
+)
+
+// Eytzinger holds a sorted slice rearranged in Eytzinger (breadth-first)
+// order: the root of an implicit balanced binary search tree comes first,
+// then its two children, then the four grandchildren, and so on.
+//
+// Binary search over a sorted slice jumps around memory, so on a large slice
+// nearly every probe is a cache miss. In Eytzinger order the first few levels
+// of the tree share a handful of cache lines and a node's descendants on any
+// later level sit next to each other, which keeps the hot part of the search in
+// cache. The search also needs no data-dependent branch: it always walks the
+// full height of the tree and works out the answer from the path it took.
+//
+// Without a branch the processor can't run ahead speculatively, so once the
+// tree is far bigger than the cache every level waits for memory. C versions
+// hide that by prefetching nodes a few levels down, which Go has no way to
+// ask for; cmd/cache_bench shows where the crossover lands.
+type Eytzinger[T sorting.Ordered] struct {
+	// nodes[k] is node k. Its children are nodes 2k and 2k+1; nodes[0] is unused.
+	nodes []eytzinger_node[T]
+	// Stats, if not nil, counts the nodes examined as probes.
+	Stats *stats.Stats
+}
+
+// eytzinger_node is an item and its index in the sorted slice. Keeping the
+// index next to the item means looking it up doesn't cost another cache miss.
+type eytzinger_node[T any] struct {
+	item T
+	rank int
+}
+
+// NewEytzinger lays out the sorted slice arr in Eytzinger order.
+// It takes O(n) time.
+func NewEytzinger[T sorting.Ordered](arr []T) *Eytzinger[T] {
+	e := &Eytzinger[T]{nodes: make([]eytzinger_node[T], len(arr)+1)}
+	e.fill(arr, 0, 1)
+	return e
+}
+
+// fill visits the subtree rooted at node k in order, giving it arr[i:] in
+// turn, and returns the index of the first item it didn't use.
+func (e *Eytzinger[T]) fill(arr []T, i, k int) int {
+	if k < len(e.nodes) {
+		i = e.fill(arr, i, 2*k)
+		e.nodes[k] = eytzinger_node[T]{arr[i], i}
+		i = e.fill(arr, i+1, 2*k+1)
+	}
+	return i
+}
+
+// Len returns the number of items.
+func (e *Eytzinger[T]) Len() int {
+	return len(e.nodes) - 1
+}
+
+// LowerBound returns the index in the original sorted slice of the first item
+// that is not less than target, or Len() if there is none, like the
+// LowerBound function.
+func (e *Eytzinger[T]) LowerBound(target T) (index, num_tests int) {
+	k, num_tests := e.lower_bound(target)
+	if k == 0 {
+		return e.Len(), num_tests
+	}
+	return e.nodes[k].rank, num_tests
+}
+
+// Search returns the index in the original sorted slice of an item equal to
+// target, or -1 if there is none.
+func (e *Eytzinger[T]) Search(target T) (index, num_tests int) {
+	k, num_tests := e.lower_bound(target)
+	if k == 0 || e.nodes[k].item != target {
+		return -1, num_tests
+	}
+	return e.nodes[k].rank, num_tests
+}
+
+// lower_bound returns the node holding the first item not less than target,
+// or 0 if there is none.
+func (e *Eytzinger[T]) lower_bound(target T) (k, num_tests int) {
+	examined_items := 0
+
+	// Go left at items not less than target and right at the others. The
+	// only branch is the loop test, which is the same for every target.
+	k = 1
+	for k < len(e.nodes) {
+		examined_items += 1
+		e.Stats.Probe(1)
+		k = 2*k + branchless.B2i(e.nodes[k].item < target)
+	}
+
+	// The answer is the last node where the path went left. Every right turn
+	// appended a 1 bit to k and every left turn a 0, so strip the trailing
+	// ones and the zero before them.
+	k >>= bits.TrailingZeros(^uint(k)) + 1
+	return k, examined_items
+}
@@ -0,0 +1,87 @@
+package search
+
+import (
+	"fmt"
+	"math/bits"
+	"testing"
+
+	"example.com/m/v2/datagen"
+	"example.com/m/v2/internal/testutil"
+	"example.com/m/v2/stats"
+)
+
+// TestEytzinger compares the Eytzinger layout's searches with LowerBound and
+// BinarySearch on the sorted slice.
+func TestEytzinger(t *testing.T) {
+	testutil.RunRounds(t, func(r *datagen.Generator, max_len int) error {
+		arr, target := sorted_ints_and_target(r, max_len)
+		e := NewEytzinger(arr)
+		e.Stats = &stats.Stats{}
+
+		want, _ := LowerBound(arr, target)
+		index, num_tests := e.LowerBound(target)
+		if index != want || e.Stats.Probes != int64(num_tests) {
+			return fmt.Errorf("Eytzinger(%s).LowerBound(%d) = %d, %d tests, %d probes; want %d and a probe per test",
+				testutil.Describe(arr), target, index, num_tests, e.Stats.Probes, want)
+		}
+		// The search walks the whole height of the tree.
+		if height := bits.Len(uint(len(arr))); num_tests < height-1 || num_tests > height {
+			return fmt.Errorf("Eytzinger(%s).LowerBound(%d) took %d tests, want about %d", testutil.Describe(arr), target, num_tests, height)
+		}
+
+		index, _ = e.Search(target)
+		first, _ := BinarySearch(arr, target)
+		if (index == -1) != (first == -1) || index != -1 && arr[index] != target {
+			return fmt.Errorf("Eytzinger(%s).Search(%d) = %d but binary search found %d", testutil.Describe(arr), target, index, first)
+		}
+		return nil
+	})
+}
+
+// lower_bound_sink keeps the benchmarked searches from being optimized away.
+var lower_bound_sink int
+
+// BenchmarkEytzingerLowerBound compares lower bound searches in the Eytzinger
+// layout and in the sorted slice, from 8 KiB of items, which fits in L1, to
+// 128 MiB, which is well beyond L3. Each op is one search for a random target.
+func BenchmarkEytzingerLowerBound(b *testing.B) {
+	searches := []struct {
+		name  string
+		build func(sorted []int) func(target int) int
+	}{
+		{"lower_bound", func(sorted []int) func(int) int {
+			return func(target int) int {
+				index, _ := LowerBound(sorted, target)
+				return index
+			}
+		}},
+		{"eytzinger", func(sorted []int) func(int) int {
+			e := NewEytzinger(sorted)
+			return func(target int) int {
+				index, _ := e.LowerBound(target)
+				return index
+			}
+		}},
+	}
+
+	for _, s := range searches {
+		s := s
+		b.Run(s.name, func(b *testing.B) {
+			for _, n := range []int{1 << 10, 1 << 14, 1 << 18, 1 << 22, 1 << 24} {
+				// Spread the items out so that half the targets are missing.
+				sorted := make([]int, n)
+				for i := range sorted {
+					sorted[i] = 2 * i
+				}
+				targets := datagen.New(1).Uniform(1<<16, 2*n)
+				b.Run(fmt.Sprintf("n=%d", n), func(b *testing.B) {
+					lower_bound := s.build(sorted)
+					b.ResetTimer()
+					for i := 0; i < b.N; i++ {
+						lower_bound_sink = lower_bound(targets[i&(len(targets)-1)])
+					}
+				})
+			}
+		})
+	}
+}
@@ -0,0 +1,133 @@
+package search
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"math/bits"
+	"strconv"
+	"testing"
+
+	"example.com/m/v2/datagen"
+	"example.com/m/v2/internal/testutil"
+	"example.com/m/v2/stats"
+)
+
+// TestRecordFile writes sorted ints as fixed-width records and checks that
+// searching them, with and without an index, agrees with LowerBound on the
+// slice.
+func TestRecordFile(t *testing.T) {
+	testutil.RunRounds(t, func(r *datagen.Generator, max_len int) error {
+		arr, target := sorted_ints_and_target(r, max_len)
+		var file bytes.Buffer
+		for _, v := range arr {
+			binary.Write(&file, binary.LittleEndian, int64(v))
+		}
+		want, want_tests := LowerBound(arr, target)
+
+		f, err := NewRecordFile(bytes.NewReader(file.Bytes()), int64(file.Len()), 8, Int64Key)
+		if err != nil {
+			return err
+		}
+		f.Stats = &stats.Stats{}
+		got, num_tests, err := f.LowerBound(int64(target))
+		if err != nil || got != want || num_tests != want_tests || f.Stats.Probes != int64(num_tests) {
+			return fmt.Errorf("RecordFile.LowerBound(%s, %d) = %d, %d tests, %d probes, %v; want %d, %d tests and as many probes",
+				testutil.Describe(arr), target, got, num_tests, f.Stats.Probes, err, want, want_tests)
+		}
+
+		every := r.Intn(5) + 1
+		if err := f.BuildIndex(every); err != nil {
+			return err
+		}
+		// The index leaves at most every records to search.
+		max_tests := bits.Len(uint(every))
+		got, num_tests, err = f.LowerBound(int64(target))
+		if err != nil || got != want || num_tests > max_tests {
+			return fmt.Errorf("RecordFile.LowerBound(%s, %d) with an index every %d = %d, %d tests, %v; want %d, at most %d tests",
+				testutil.Describe(arr), target, every, got, num_tests, err, want, max_tests)
+		}
+		return nil
+	})
+}
+
+// eof_reader is a ReaderAt that returns io.EOF along with a read that
+// reaches the end of the data, which ReadAt is allowed to do.
+type eof_reader []byte
+
+func (r eof_reader) ReadAt(p []byte, off int64) (int, error) {
+	if off >= int64(len(r)) {
+		return 0, io.EOF
+	}
+	n := copy(p, r[off:])
+	if off+int64(n) == int64(len(r)) {
+		return n, io.EOF
+	}
+	return n, nil
+}
+
+// TestRecordFileEOF checks that the last record can be read from a ReaderAt
+// that returns io.EOF with it.
+func TestRecordFileEOF(t *testing.T) {
+	var file bytes.Buffer
+	for _, v := range []int64{1, 3, 5} {
+		binary.Write(&file, binary.LittleEndian, v)
+	}
+	f, err := NewRecordFile(eof_reader(file.Bytes()), int64(file.Len()), 8, Int64Key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i, want := range []int64{1, 3, 5} {
+		if got, err := f.Key(i); err != nil || got != want {
+			t.Errorf("Key(%d) = %d, %v; want %d", i, got, err, want)
+		}
+	}
+	if got, _, err := f.LowerBound(5); err != nil || got != 2 {
+		t.Errorf("LowerBound(5) = %d, %v; want 2", got, err)
+	}
+}
+
+// TestLineFile writes sorted ints one per line, padded to random widths so
+// the lines vary in length, and checks that searching the text agrees with
+// LowerBound on the slice.
+func TestLineFile(t *testing.T) {
+	testutil.RunRounds(t, func(r *datagen.Generator, max_len int) error {
+		arr, target := sorted_ints_and_target(r, max_len)
+		var file bytes.Buffer
+		var offsets []int64
+		for _, v := range arr {
+			offsets = append(offsets, int64(file.Len()))
+			fmt.Fprintf(&file, "%*d\n", r.Intn(6), v)
+		}
+		offsets = append(offsets, int64(file.Len()))
+		want, _ := LowerBound(arr, target)
+
+		f := NewLineFile(bytes.NewReader(file.Bytes()), int64(file.Len()), IntKey)
+		for _, every := range []int{0, r.Intn(5) + 1} {
+			if every > 0 {
+				if err := f.BuildIndex(every); err != nil {
+					return err
+				}
+			}
+			got, _, err := f.LowerBound(int64(target))
+			if err != nil || got != offsets[want] {
+				return fmt.Errorf("LineFile.LowerBound(%q, %d) with an index every %d = %d, %v; want %d",
+					file.String(), target, every, got, err, offsets[want])
+			}
+
+			line_offset, _, err := f.Find(int64(target))
+			found := want < len(arr) && arr[want] == target
+			if err != nil || (line_offset >= 0) != found {
+				return fmt.Errorf("LineFile.Find(%q, %d) = %d, %v", file.String(), target, line_offset, err)
+			}
+			if found {
+				line, _ := f.Line(line_offset)
+				if v, _ := IntKey(line); v != int64(target) {
+					return fmt.Errorf("LineFile.Find(%q, %d) found line %s", file.String(), target, strconv.Quote(line))
+				}
+			}
+		}
+		return nil
+	})
+}
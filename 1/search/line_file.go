@@ -0,0 +1,201 @@
+package search
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"example.com/m/v2/sorting"
+	"example.com/m/v2/stats"
+)
+
+// LineFile binary searches a text file of lines sorted by key without
+// loading it. It searches on byte offsets: from any offset it skips to the
+// start of the next line and reads that line, so lines may have any length.
+//
+// num_tests counts the lines read. BuildIndex keeps sampled lines in memory
+// to narrow each search before it touches the file.
+type LineFile[K sorting.Ordered] struct {
+	r    io.ReaderAt
+	size int64
+	key  func(line string) (K, error)
+	buf  []byte
+
+	// index holds the offset and key of sampled lines, if built.
+	index_offsets []int64
+	index_keys    []K
+
+	// Stats, if not nil, counts the lines read by the searches as probes.
+	Stats *stats.Stats
+}
+
+// The number of bytes read at a time while looking for a line.
+const line_chunk_size = 512
+
+// NewLineFile searches the size bytes of r as lines ordered by key.
+func NewLineFile[K sorting.Ordered](r io.ReaderAt, size int64, key func(line string) (K, error)) *LineFile[K] {
+	return &LineFile[K]{r: r, size: size, key: key, buf: make([]byte, line_chunk_size)}
+}
+
+// StringKey orders lines by their text.
+func StringKey(line string) (string, error) {
+	return line, nil
+}
+
+// IntKey orders lines by the decimal integer they hold, as written by
+// extsort's text format.
+func IntKey(line string) (int64, error) {
+	return strconv.ParseInt(strings.TrimSpace(line), 10, 64)
+}
+
+// Size returns the size of the file in bytes.
+func (f *LineFile[K]) Size() int64 {
+	return f.size
+}
+
+// Line returns the line that starts at offset, without its line ending.
+func (f *LineFile[K]) Line(offset int64) (string, error) {
+	var line []byte
+	for pos := offset; pos < f.size; {
+		n, err := f.r.ReadAt(f.buf, pos)
+		if n == 0 && err != nil {
+			return "", err
+		}
+		chunk := f.buf[:n]
+		if end := bytes.IndexByte(chunk, '\n'); end >= 0 {
+			line = append(line, chunk[:end]...)
+			break
+		}
+		line = append(line, chunk...)
+		pos += int64(n)
+	}
+	return strings.TrimSuffix(string(line), "\r"), nil
+}
+
+// next_line returns the offset of the first line that starts at or after
+// offset, or the file size if there is none.
+func (f *LineFile[K]) next_line(offset int64) (int64, error) {
+	if offset <= 0 {
+		return 0, nil
+	}
+
+	// A line starts at offset only if the byte before it ends a line.
+	for pos := offset - 1; pos < f.size; {
+		n, err := f.r.ReadAt(f.buf, pos)
+		if n == 0 && err != nil {
+			return 0, err
+		}
+		if end := bytes.IndexByte(f.buf[:n], '\n'); end >= 0 {
+			return pos + int64(end) + 1, nil
+		}
+		pos += int64(n)
+	}
+	return f.size, nil
+}
+
+// key_at returns the key of the line starting at offset.
+func (f *LineFile[K]) key_at(offset int64) (K, error) {
+	line, err := f.Line(offset)
+	if err != nil {
+		var zero K
+		return zero, err
+	}
+	k, err := f.key(line)
+	if err != nil {
+		return k, fmt.Errorf("search: line at offset %d: %v", offset, err)
+	}
+	return k, nil
+}
+
+// BuildIndex reads the whole file once and keeps the offset and key of every
+// every'th line in memory so later searches need fewer reads.
+func (f *LineFile[K]) BuildIndex(every int) error {
+	if every <= 0 {
+		return fmt.Errorf("search: index spacing must be positive, got %d", every)
+	}
+
+	var offsets []int64
+	var keys []K
+	reader := bufio.NewReader(io.NewSectionReader(f.r, 0, f.size))
+	offset := int64(0)
+	for n := 0; offset < f.size; n++ {
+		line, err := reader.ReadString('\n')
+		if err != nil && err != io.EOF {
+			return err
+		}
+		if n%every == 0 {
+			k, err := f.key(strings.TrimSuffix(strings.TrimSuffix(line, "\n"), "\r"))
+			if err != nil {
+				return fmt.Errorf("search: line at offset %d: %v", offset, err)
+			}
+			offsets = append(offsets, offset)
+			keys = append(keys, k)
+		}
+		offset += int64(len(line))
+	}
+	f.index_offsets, f.index_keys = offsets, keys
+	return nil
+}
+
+// LowerBound returns the offset of the first line whose key is not less than
+// target, or Size() if there is none.
+func (f *LineFile[K]) LowerBound(target K) (offset int64, num_tests int, err error) {
+	lo, hi := f.index_range(target)
+
+	// Find the smallest offset whose next line is not less than target; that
+	// line is the answer. Search works on ints, which hold any file offset
+	// on 64-bit systems.
+	found, num_tests := search(int(lo), int(hi), func(x int) bool {
+		if err != nil {
+			return true
+		}
+		var start int64
+		if start, err = f.next_line(int64(x)); err != nil || start == f.size {
+			return true
+		}
+		var k K
+		k, err = f.key_at(start)
+		return err == nil && k >= target
+	}, f.Stats)
+	if err != nil {
+		return -1, num_tests, err
+	}
+	offset, err = f.next_line(int64(found))
+	return offset, num_tests, err
+}
+
+// Find returns the offset of the first line whose key equals target, or -1
+// if there is none.
+func (f *LineFile[K]) Find(target K) (offset int64, num_tests int, err error) {
+	offset, num_tests, err = f.LowerBound(target)
+	if err != nil || offset == f.size {
+		return -1, num_tests, err
+	}
+	k, err := f.key_at(offset)
+	if err != nil || k != target {
+		return -1, num_tests, err
+	}
+	return offset, num_tests, nil
+}
+
+// index_range uses the index, if there is one, to narrow the offsets to
+// search to [lo, hi].
+func (f *LineFile[K]) index_range(target K) (lo, hi int64) {
+	if f.index_keys == nil {
+		return 0, f.size
+	}
+
+	// Sampled lines before k are less than target and lines from k on are not.
+	k, _ := LowerBound(f.index_keys, target)
+	if k > 0 {
+		lo = f.index_offsets[k-1] + 1
+	}
+	hi = f.size
+	if k < len(f.index_keys) {
+		hi = f.index_offsets[k]
+	}
+	return lo, hi
+}
@@ -0,0 +1,33 @@
+// Package search holds the searching algorithms used by the chapter 1 demos.
+//
+// Every search also returns num_tests, the number of array items it examined,
+// so the algorithms can be compared with each other. The Stats variants of
+// the functions, and the Stats fields of the types, also record the items
+// examined as probes in a stats.Stats, like the rest of the algorithms.
+package search
+
+import "example.com/m/v2/stats"
+
+// LinearSearch returns the index of the first item equal to target, or -1 if
+// there is none. arr does not need to be sorted.
+func LinearSearch[T comparable](arr []T, target T) (index, num_tests int) {
+	return linear_search(arr, target, nil)
+}
+
+// LinearSearchStats is like LinearSearch but records the items it examines
+// in s as probes.
+func LinearSearchStats[T comparable](arr []T, target T, s *stats.Stats) (index, num_tests int) {
+	return linear_search(arr, target, s)
+}
+
+func linear_search[T comparable](arr []T, target T, s *stats.Stats) (index, num_tests int) {
+	// If the value is in the list.
+	for i, v := range arr {
+		s.Probe(1)
+		if v == target {
+			return i, i + 1
+		}
+	}
+	// Otherwise.
+	return -1, len(arr)
+}
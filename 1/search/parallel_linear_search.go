@@ -0,0 +1,156 @@
+package search
+
+import (
+	"context"
+	"runtime"
+	"sync"
+	"sync/atomic"
+
+	"example.com/m/v2/stats"
+)
+
+// The parallel searches hand out the slice in blocks of this many items. They
+// check for cancellation, and for a match found by another worker, between
+// blocks.
+const search_block_size = 1 << 12
+
+// ParallelLinearSearch returns the index of the first item equal to target,
+// or -1 if there is none, like LinearSearch. It scans blocks of arr on up to
+// parallelism goroutines; if parallelism is not positive,
+// runtime.GOMAXPROCS(0) is used.
+//
+// Once a match is found the workers stop as soon as they reach a block past
+// it. If ctx is cancelled or its deadline passes first, the search stops and
+// returns ctx's error. num_tests counts the items examined by every worker.
+func ParallelLinearSearch[T comparable](ctx context.Context, arr []T, target T, parallelism int) (index, num_tests int, err error) {
+	return parallel_find(ctx, arr, func(v T) bool { return v == target }, parallelism, nil)
+}
+
+// ParallelLinearSearchStats is like ParallelLinearSearch but records the
+// items examined by every worker in s as probes.
+func ParallelLinearSearchStats[T comparable](ctx context.Context, arr []T, target T, parallelism int, s *stats.Stats) (index, num_tests int, err error) {
+	return parallel_find(ctx, arr, func(v T) bool { return v == target }, parallelism, s)
+}
+
+// ParallelFind is like ParallelLinearSearch but looks for the first item for
+// which pred is true. pred is called from several goroutines at once.
+func ParallelFind[T any](ctx context.Context, arr []T, pred func(v T) bool, parallelism int) (index, num_tests int, err error) {
+	return parallel_find(ctx, arr, pred, parallelism, nil)
+}
+
+func parallel_find[T any](ctx context.Context, arr []T, pred func(v T) bool, parallelism int, s *stats.Stats) (index, num_tests int, err error) {
+	var first atomic.Int64
+	first.Store(int64(len(arr)))
+
+	visit := func(lo, hi int) int {
+		for i := lo; i < hi; i++ {
+			if pred(arr[i]) {
+				// Keep the earliest match any worker has found.
+				for {
+					current := first.Load()
+					if int64(i) >= current || first.CompareAndSwap(current, int64(i)) {
+						break
+					}
+				}
+				return i - lo + 1
+			}
+		}
+		return hi - lo
+	}
+	past_match := func(lo int) bool {
+		return int64(lo) > first.Load()
+	}
+
+	num_tests, err = scan_blocks(ctx, len(arr), parallelism, visit, past_match, s)
+	if err != nil {
+		return -1, num_tests, err
+	}
+	if index := int(first.Load()); index < len(arr) {
+		return index, num_tests, nil
+	}
+	return -1, num_tests, nil
+}
+
+// ParallelFindAll returns the indexes of every item for which pred is true,
+// in ascending order. It examines every item unless ctx is cancelled, in
+// which case it returns ctx's error.
+func ParallelFindAll[T any](ctx context.Context, arr []T, pred func(v T) bool, parallelism int) (indexes []int, num_tests int, err error) {
+	// Each block's matches go in their own slot so no locking is needed.
+	matches := make([][]int, (len(arr)+search_block_size-1)/search_block_size)
+	visit := func(lo, hi int) int {
+		for i := lo; i < hi; i++ {
+			if pred(arr[i]) {
+				matches[lo/search_block_size] = append(matches[lo/search_block_size], i)
+			}
+		}
+		return hi - lo
+	}
+
+	num_tests, err = scan_blocks(ctx, len(arr), parallelism, visit, func(lo int) bool { return false }, nil)
+	if err != nil {
+		return nil, num_tests, err
+	}
+	for _, m := range matches {
+		indexes = append(indexes, m...)
+	}
+	return indexes, num_tests, nil
+}
+
+// scan_blocks calls visit for the blocks of n items in ascending order of
+// their start, spread over up to parallelism goroutines, until every block
+// has been visited, stop(lo) is true for the next block or ctx is cancelled.
+// visit returns the number of items it examined, and scan_blocks returns the
+// total and records it in s as probes.
+func scan_blocks(ctx context.Context, n, parallelism int, visit func(lo, hi int) int, stop func(lo int) bool, s *stats.Stats) (num_tests int, err error) {
+	num_blocks := (n + search_block_size - 1) / search_block_size
+	if parallelism <= 0 {
+		parallelism = runtime.GOMAXPROCS(0)
+	}
+	if parallelism > num_blocks {
+		parallelism = num_blocks
+	}
+
+	var next_block, examined atomic.Int64
+	var cancelled atomic.Bool
+	worker := func() {
+		for {
+			b := int(next_block.Add(1) - 1)
+			if b >= num_blocks {
+				return
+			}
+			if ctx.Err() != nil {
+				cancelled.Store(true)
+				return
+			}
+			lo := b * search_block_size
+			if stop(lo) {
+				// Blocks are handed out in order, so every later block would stop too.
+				return
+			}
+			hi := lo + search_block_size
+			if hi > n {
+				hi = n
+			}
+			num_examined := visit(lo, hi)
+			examined.Add(int64(num_examined))
+			s.Probe(num_examined)
+		}
+	}
+
+	// The caller's goroutine is one of the workers.
+	var wg sync.WaitGroup
+	for i := 1; i < parallelism; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			worker()
+		}()
+	}
+	worker()
+	wg.Wait()
+
+	if cancelled.Load() {
+		return int(examined.Load()), ctx.Err()
+	}
+	return int(examined.Load()), nil
+}
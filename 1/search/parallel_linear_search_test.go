@@ -0,0 +1,56 @@
+package search
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"example.com/m/v2/datagen"
+	"example.com/m/v2/internal/testutil"
+)
+
+// TestParallelLinearSearch compares the parallel searches with linear search
+// on slices long enough to be split into several blocks.
+func TestParallelLinearSearch(t *testing.T) {
+	testutil.RunRounds(t, func(r *datagen.Generator, max_len int) error {
+		arr := make([]int, r.Intn(max_len*1000+1))
+		for i := range arr {
+			arr[i] = r.Intn(len(arr) + 1)
+		}
+		target := r.Intn(len(arr) + 1)
+		parallelism := r.Intn(4) + 1
+		want, _ := LinearSearch(arr, target)
+
+		index, num_tests, err := ParallelLinearSearch(context.Background(), arr, target, parallelism)
+		if err != nil || index != want || num_tests < want+1 || num_tests > len(arr) {
+			return fmt.Errorf("ParallelLinearSearch of %d items for %d on %d goroutines = %d, %d tests, %v; want %d",
+				len(arr), target, parallelism, index, num_tests, err, want)
+		}
+
+		multiple := func(v int) bool { return v%97 == 0 }
+		all, num_tests, err := ParallelFindAll(context.Background(), arr, multiple, parallelism)
+		if err != nil || num_tests != len(arr) {
+			return fmt.Errorf("ParallelFindAll of %d items examined %d, %v", len(arr), num_tests, err)
+		}
+		next := 0
+		for i, v := range arr {
+			if multiple(v) {
+				if next >= len(all) || all[next] != i {
+					return fmt.Errorf("ParallelFindAll of %d items = %v, missing %d", len(arr), all, i)
+				}
+				next++
+			}
+		}
+		if next != len(all) {
+			return fmt.Errorf("ParallelFindAll of %d items found %d matches, want %d", len(arr), len(all), next)
+		}
+
+		// A cancelled search gives up.
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		if _, _, err := ParallelFind(ctx, arr, multiple, parallelism); len(arr) > 0 && err != context.Canceled {
+			return fmt.Errorf("ParallelFind with a cancelled context returned %v", err)
+		}
+		return nil
+	})
+}
@@ -0,0 +1,165 @@
+package search
+
+import (
+	"encoding/binary"
+	"fmt"
+	"io"
+
+	"example.com/m/v2/sorting"
+	"example.com/m/v2/stats"
+)
+
+// RecordFile binary searches a file of fixed-width records sorted by key,
+// reading only the records it examines instead of loading the whole file.
+//
+// num_tests counts the records read from the file. After BuildIndex the
+// search starts by looking at sampled keys held in memory, which narrows the
+// range before touching the file, so it reads fewer records.
+type RecordFile[K sorting.Ordered] struct {
+	r           io.ReaderAt
+	record_size int
+	num_records int
+	key         func(record []byte) K
+	buf         []byte
+
+	// index holds the key of every index_every'th record, if built.
+	index       []K
+	index_every int
+
+	// Stats, if not nil, counts the records read by the searches as probes.
+	Stats *stats.Stats
+}
+
+// NewRecordFile searches the size bytes of r as records of record_size
+// bytes, ordered by key. size must be a whole number of records.
+func NewRecordFile[K sorting.Ordered](r io.ReaderAt, size int64, record_size int, key func(record []byte) K) (*RecordFile[K], error) {
+	if record_size <= 0 {
+		return nil, fmt.Errorf("search: record size must be positive, got %d", record_size)
+	}
+	if size%int64(record_size) != 0 {
+		return nil, fmt.Errorf("search: file size %d is not a multiple of the record size %d", size, record_size)
+	}
+	return &RecordFile[K]{
+		r:           r,
+		record_size: record_size,
+		num_records: int(size / int64(record_size)),
+		key:         key,
+		buf:         make([]byte, record_size),
+	}, nil
+}
+
+// Len returns the number of records in the file.
+func (f *RecordFile[K]) Len() int {
+	return f.num_records
+}
+
+// Record returns record i. The slice is reused by the next read.
+func (f *RecordFile[K]) Record(i int) ([]byte, error) {
+	if i < 0 || i >= f.num_records {
+		return nil, fmt.Errorf("search: record %d out of range [0, %d)", i, f.num_records)
+	}
+	// ReadAt may return io.EOF along with the last record, so only a
+	// short read is an error.
+	if n, err := f.r.ReadAt(f.buf, int64(i)*int64(f.record_size)); n < f.record_size {
+		if err == nil {
+			err = io.ErrUnexpectedEOF
+		}
+		return nil, err
+	}
+	return f.buf, nil
+}
+
+// Key returns the key of record i.
+func (f *RecordFile[K]) Key(i int) (K, error) {
+	record, err := f.Record(i)
+	if err != nil {
+		var zero K
+		return zero, err
+	}
+	return f.key(record), nil
+}
+
+// BuildIndex reads the key of every every'th record into memory so later
+// searches need fewer reads. It costs Len()/every reads once.
+func (f *RecordFile[K]) BuildIndex(every int) error {
+	if every <= 0 {
+		return fmt.Errorf("search: index spacing must be positive, got %d", every)
+	}
+	index := make([]K, 0, (f.num_records+every-1)/every)
+	for i := 0; i < f.num_records; i += every {
+		k, err := f.Key(i)
+		if err != nil {
+			return err
+		}
+		index = append(index, k)
+	}
+	f.index, f.index_every = index, every
+	return nil
+}
+
+// LowerBound returns the index of the first record whose key is not less than
+// target, or Len() if there is none.
+func (f *RecordFile[K]) LowerBound(target K) (index, num_tests int, err error) {
+	lo, hi := f.index_range(target)
+	index, num_tests = search(lo, hi, func(i int) bool {
+		if err != nil {
+			// Stop as quickly as possible.
+			return true
+		}
+		var k K
+		k, err = f.Key(i)
+		return err == nil && k >= target
+	}, f.Stats)
+	if err != nil {
+		return -1, num_tests, err
+	}
+	return index, num_tests, nil
+}
+
+// Find returns the index of the first record whose key equals target, or -1
+// if there is none.
+func (f *RecordFile[K]) Find(target K) (index, num_tests int, err error) {
+	i, num_tests, err := f.LowerBound(target)
+	if err != nil || i == f.num_records {
+		return -1, num_tests, err
+	}
+	k, err := f.Key(i)
+	if err != nil {
+		return -1, num_tests, err
+	}
+	if k != target {
+		return -1, num_tests, nil
+	}
+	return i, num_tests, nil
+}
+
+// index_range uses the index, if there is one, to narrow the records that
+// can hold the lower bound of target to [lo, hi].
+func (f *RecordFile[K]) index_range(target K) (lo, hi int) {
+	if f.index == nil {
+		return 0, f.num_records
+	}
+
+	// Samples before k are less than target and samples from k on are not.
+	k, _ := LowerBound(f.index, target)
+	if k > 0 {
+		lo = (k-1)*f.index_every + 1
+	}
+	hi = f.num_records
+	if k < len(f.index) {
+		hi = k * f.index_every
+	}
+	return lo, hi
+}
+
+// Int32Key reads a record that is a 4-byte little-endian signed integer, as
+// written by extsort's binary32 format.
+func Int32Key(record []byte) int64 {
+	return int64(int32(binary.LittleEndian.Uint32(record)))
+}
+
+// Int64Key reads a record that is an 8-byte little-endian signed integer, as
+// written by extsort's binary64 format.
+func Int64Key(record []byte) int64 {
+	return int64(binary.LittleEndian.Uint64(record))
+}
@@ -0,0 +1,112 @@
+package search
+
+import (
+	"example.com/m/v2/sorting"
+	"example.com/m/v2/stats"
+)
+
+// SuffixIndex answers substring queries on a text using its suffix array.
+//
+// Every occurrence of a pattern is the start of a suffix that begins with
+// the pattern, and in the suffix array those suffixes form one contiguous
+// range. Two binary searches find the range, so a query takes
+// O(m log n) time for a pattern of length m, however often it occurs.
+type SuffixIndex struct {
+	text string
+	sa   []int
+	lcp  []int
+
+	// Stats, if not nil, counts the suffixes examined as probes.
+	Stats *stats.Stats
+}
+
+// NewSuffixIndex builds the suffix array and LCP array of text in O(n) time.
+func NewSuffixIndex(text string) *SuffixIndex {
+	sa := sorting.SuffixArray(text)
+	return &SuffixIndex{text: text, sa: sa, lcp: sorting.LCPArray(text, sa)}
+}
+
+// Text returns the indexed text.
+func (x *SuffixIndex) Text() string {
+	return x.text
+}
+
+// SuffixArray returns the suffix array. It must not be modified.
+func (x *SuffixIndex) SuffixArray() []int {
+	return x.sa
+}
+
+// LCP returns the longest common prefix array. It must not be modified.
+func (x *SuffixIndex) LCP() []int {
+	return x.lcp
+}
+
+// Range returns the range sa[lo:hi] of the suffix array holding the suffixes
+// that start with pattern. If there are none, lo == hi is where they would go.
+func (x *SuffixIndex) Range(pattern string) (lo, hi, num_tests int) {
+	n := len(x.sa)
+
+	// prefix returns the first len(pattern) bytes of the suffix at sa[i].
+	prefix := func(i int) string {
+		start := x.sa[i]
+		end := start + len(pattern)
+		if end > len(x.text) {
+			end = len(x.text)
+		}
+		return x.text[start:end]
+	}
+
+	lo, lower_tests := search(0, n, func(i int) bool { return prefix(i) >= pattern }, x.Stats)
+	hi, upper_tests := search(lo, n, func(i int) bool { return prefix(i) > pattern }, x.Stats)
+	return lo, hi, lower_tests + upper_tests
+}
+
+// Count returns the number of times pattern occurs in the text, counting
+// overlapping occurrences. The empty pattern occurs at every index.
+func (x *SuffixIndex) Count(pattern string) (count, num_tests int) {
+	lo, hi, num_tests := x.Range(pattern)
+	return hi - lo, num_tests
+}
+
+// Find returns the indexes in the text where pattern occurs, in increasing order.
+func (x *SuffixIndex) Find(pattern string) (indexes []int, num_tests int) {
+	lo, hi, num_tests := x.Range(pattern)
+	indexes = append([]int(nil), x.sa[lo:hi]...)
+	sorting.Quicksort(indexes)
+	return indexes, num_tests
+}
+
+// LongestRepeated returns the longest substring that occurs at least twice in
+// the text, and the index of its first occurrence. It returns "" and -1 if no
+// byte repeats.
+func (x *SuffixIndex) LongestRepeated() (substring string, index int) {
+	// Any repeated substring is a common prefix of two suffixes, and the
+	// longest common prefixes are between neighbours in the suffix array.
+	best := 0
+	for i := 1; i < len(x.lcp); i++ {
+		if x.lcp[i] > x.lcp[best] {
+			best = i
+		}
+	}
+	if len(x.lcp) == 0 || x.lcp[best] == 0 {
+		return "", -1
+	}
+
+	length := x.lcp[best]
+	index = x.sa[best]
+	if x.sa[best-1] < index {
+		index = x.sa[best-1]
+	}
+	// The substring may occur more than twice, possibly earlier still.
+	for i := best - 1; i > 0 && x.lcp[i] >= length; i-- {
+		if x.sa[i-1] < index {
+			index = x.sa[i-1]
+		}
+	}
+	for i := best + 1; i < len(x.lcp) && x.lcp[i] >= length; i++ {
+		if x.sa[i] < index {
+			index = x.sa[i]
+		}
+	}
+	return x.text[index : index+length], index
+}
@@ -0,0 +1,53 @@
+package search
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"example.com/m/v2/datagen"
+	"example.com/m/v2/internal/testutil"
+)
+
+// TestSuffixIndex compares substring searches with strings.Index.
+func TestSuffixIndex(t *testing.T) {
+	testutil.RunRounds(t, func(r *datagen.Generator, max_len int) error {
+		text := testutil.RandomText(r, max_len)
+		x := NewSuffixIndex(text)
+
+		// Look for a piece of the text about half the time.
+		pattern := testutil.RandomText(r, 4)
+		if len(text) > 0 && r.Intn(2) == 0 {
+			start := r.Intn(len(text))
+			pattern = text[start : start+r.Intn(len(text)-start)+1]
+		}
+
+		var want []int
+		for i := 0; i <= len(text)-len(pattern); i++ {
+			if strings.HasPrefix(text[i:], pattern) && (pattern != "" || i < len(text)) {
+				want = append(want, i)
+			}
+		}
+		got, _ := x.Find(pattern)
+		if !testutil.Equal(got, want) {
+			return fmt.Errorf("Find(%q) in %q = %v, want %v", pattern, text, got, want)
+		}
+		if count, _ := x.Count(pattern); count != len(want) {
+			return fmt.Errorf("Count(%q) in %q = %d, want %d", pattern, text, count, len(want))
+		}
+
+		// The longest repeated substring first occurs at index and occurs again
+		// later, but nothing one byte longer occurs twice.
+		substring, index := x.LongestRepeated()
+		if index >= 0 && (strings.Index(text, substring) != index || strings.LastIndex(text, substring) == index) {
+			return fmt.Errorf("LongestRepeated of %q = %q at %d, which doesn't repeat there", text, substring, index)
+		}
+		for i := 0; i+len(substring) < len(text); i++ {
+			longer := text[i : i+len(substring)+1]
+			if strings.Index(text, longer) != strings.LastIndex(text, longer) {
+				return fmt.Errorf("LongestRepeated of %q = %q but %q repeats", text, substring, longer)
+			}
+		}
+		return nil
+	})
+}
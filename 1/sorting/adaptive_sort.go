@@ -0,0 +1,151 @@
+package sorting
+
+import (
+	"fmt"
+
+	"example.com/m/v2/stats"
+)
+
+// AdaptiveSort measures the presortedness of long inputs on a sample of
+// adaptive_windows evenly spaced windows of adaptive_window_size items.
+const (
+	adaptive_windows     = 8
+	adaptive_window_size = 32
+)
+
+// When insertion sort is picked from a sample, it gives up and hands over to
+// quicksort after adaptive_move_budget moves per item.
+const adaptive_move_budget = 4
+
+// AdaptiveChoice says which algorithm AdaptiveSort used and why.
+type AdaptiveChoice struct {
+	// Algorithm is the registered name of the sort that was run.
+	Algorithm string `json:"algorithm"`
+	// Reason explains the choice in a few words.
+	Reason string `json:"reason"`
+	// Presortedness holds the measures the choice was based on.
+	Presortedness Presortedness `json:"presortedness"`
+}
+
+// AdaptiveSort sorts arr in place with whichever of insertion sort, Timsort
+// and quicksort suits it best, and reports its choice.
+//
+// Long inputs aren't measured in full, since that would cost as much as
+// sorting them. Runs are counted exactly in one pass, and the other measures
+// are estimated from a sample of short windows spread across arr, so they
+// can miss disorder that falls between the windows. When insertion sort is
+// picked on a sampled estimate it is given a budget of a few moves per item,
+// and if that runs out quicksort finishes the job, which keeps the worst case
+// O(n log n). It is not stable.
+func AdaptiveSort[T Ordered](arr []T) AdaptiveChoice {
+	return AdaptiveSortFunc(arr, Less[T])
+}
+
+// AdaptiveSortFunc is like AdaptiveSort but orders the items using less.
+func AdaptiveSortFunc[T any](arr []T, less func(a, b T) bool) AdaptiveChoice {
+	return adaptive_sort(arr, less, nil)
+}
+
+func adaptive_sort[T any](arr []T, less func(a, b T) bool, s *stats.Stats) AdaptiveChoice {
+	choice := choose_sort(arr, less, s)
+	switch choice.Algorithm {
+	case "insertion_sort":
+		if !choice.Presortedness.Sampled {
+			insertion_sort(arr, less, s)
+			break
+		}
+		// The sample may have missed some disorder, so don't trust it
+		// with a quadratic sort.
+		budget := adaptive_move_budget * len(arr)
+		if !bounded_insertion_sort(arr, budget, less, s) {
+			choice.Algorithm = "quicksort"
+			choice.Reason = fmt.Sprintf("insertion sort gave up after %d moves (estimated %s)", budget, choice.Reason)
+			quicksort(arr, less, s)
+		}
+	case "timsort":
+		timsort(arr, less, s)
+	default:
+		quicksort(arr, less, s)
+	}
+	return choice
+}
+
+// bounded_insertion_sort is insertion sort that gives up once it has moved
+// items budget places in all. It reports whether it finished. Either way arr
+// holds the same items, so another sort can take over.
+func bounded_insertion_sort[T any](arr []T, budget int, less func(a, b T) bool, s *stats.Stats) bool {
+	for i := 1; i < len(arr); i++ {
+		for j := i; j > 0 && less(arr[j], arr[j-1]); j-- {
+			if budget == 0 {
+				return false
+			}
+			budget--
+			arr[j], arr[j-1] = arr[j-1], arr[j]
+			s.Swap()
+		}
+	}
+	return true
+}
+
+// choose_sort picks the sort for arr.
+func choose_sort[T any](arr []T, less func(a, b T) bool, s *stats.Stats) AdaptiveChoice {
+	n := len(arr)
+	p := sample_presortedness(arr, less, s)
+	choose := func(algorithm, format string, args ...any) AdaptiveChoice {
+		return AdaptiveChoice{Algorithm: algorithm, Reason: fmt.Sprintf(format, args...), Presortedness: p}
+	}
+
+	switch {
+	case n <= insertion_sort_cutoff:
+		return choose("insertion_sort", "only %d items", n)
+
+	// Insertion sort takes O(n + inversions) time, and each item is moved
+	// at most MaxDisplacement places.
+	case p.Inversions <= 2*n:
+		return choose("insertion_sort", "about %d inversions, at most 2 per item", p.Inversions)
+	case p.MaxDisplacement <= insertion_sort_cutoff:
+		return choose("insertion_sort", "no item is more than %d places from home", p.MaxDisplacement)
+
+	// Timsort merges the runs it finds in O(n log runs) time. That only
+	// beats quicksort comfortably if log runs is well under log n. The items
+	// that are out of place each break at most two runs, and binary
+	// insertion sort puts them back while Timsort extends the short runs.
+	case p.Runs*p.Runs <= n:
+		return choose("timsort", "%d runs averaging %d items", p.Runs, n/p.Runs)
+	case n-p.LongestIncreasing <= n/16:
+		return choose("timsort", "only about %d items out of place", n-p.LongestIncreasing)
+	}
+	return choose("quicksort", "%d runs averaging %d items", p.Runs, n/p.Runs)
+}
+
+// sample_presortedness measures arr, or estimates the measures from a sample
+// if arr is long.
+func sample_presortedness[T any](arr []T, less func(a, b T) bool, s *stats.Stats) Presortedness {
+	n := len(arr)
+	m := adaptive_windows * adaptive_window_size
+	if n <= m {
+		return measure_presortedness(arr, less, s)
+	}
+
+	// Take windows at even steps, the last one ending at the end of arr.
+	sample := make([]T, 0, m)
+	s.Alloc(m)
+	for w := 0; w < adaptive_windows; w++ {
+		start := w * (n - adaptive_window_size) / (adaptive_windows - 1)
+		sample = append(sample, arr[start:start+adaptive_window_size]...)
+	}
+	p := measure_presortedness(sample, less, s)
+
+	// Scale the sample's measures up to the whole slice. Inversions are
+	// pairs, so they grow with the square of the length.
+	scale := float64(n) / float64(m)
+	p.Items = n
+	p.Inversions = int(float64(p.Inversions) * scale * scale)
+	p.LongestIncreasing = n - int(float64(m-p.LongestIncreasing)*scale)
+	p.MaxDisplacement = int(float64(p.MaxDisplacement) * scale)
+	p.Sampled = true
+
+	// Runs are cheap enough to count exactly.
+	p.Runs = RunsFunc(arr, less)
+	return p
+}
@@ -0,0 +1,50 @@
+package sorting
+
+import (
+	"fmt"
+	"io"
+)
+
+// PrintArray writes the first num_items items of arr to w on a single line.
+func PrintArray[T any](w io.Writer, arr []T, num_items int) {
+	if num_items > len(arr) {
+		num_items = len(arr)
+	}
+
+	for i := 0; i < num_items; i++ {
+		fmt.Fprintf(w, "%v ", arr[i])
+	}
+	fmt.Fprintln(w)
+}
+
+// IsSorted reports whether arr is in non-decreasing order.
+func IsSorted[T Ordered](arr []T) bool {
+	return IsSortedFunc(arr, Less[T])
+}
+
+// IsSortedFunc reports whether arr is in non-decreasing order according to less.
+func IsSortedFunc[T any](arr []T, less func(a, b T) bool) bool {
+	// Check every adjacent element.
+	// An array with 0 or 1 elements is trivially sorted.
+	for i := 0; i < len(arr)-1; i++ {
+		// If the array is sorted, we expect arr[i]<=arr[i+1].
+		if less(arr[i+1], arr[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+// CheckSorted writes a message to w saying whether arr is sorted.
+func CheckSorted[T Ordered](w io.Writer, arr []T) {
+	CheckSortedFunc(w, arr, Less[T])
+}
+
+// CheckSortedFunc is like CheckSorted but orders the items using less.
+func CheckSortedFunc[T any](w io.Writer, arr []T, less func(a, b T) bool) {
+	if IsSortedFunc(arr, less) {
+		fmt.Fprintln(w, "The array is sorted")
+	} else {
+		fmt.Fprintln(w, "The array is NOT sorted!")
+	}
+}
@@ -0,0 +1,194 @@
+package sorting
+
+import (
+	"example.com/m/v2/internal/branchless"
+	"example.com/m/v2/stats"
+)
+
+// Items are examined this many at a time by the block partition. Offsets
+// within a block must fit in a uint8.
+const partition_block_size = 128
+
+// block_offsets holds the offsets of the misplaced items found in one block.
+type block_offsets [partition_block_size]uint8
+
+// block_scanners find the items in a block that belong on the other side of
+// the pivot. Each records their offsets and returns how many there are.
+type block_scanners[T any] struct {
+	// left looks for items that are not less than pivot, from the front of block.
+	left func(block []T, pivot T, offsets *block_offsets) int
+	// right looks for items that pivot is not less than, from the back of block.
+	right func(block []T, pivot T, offsets *block_offsets) int
+}
+
+// BlockQuicksort sorts arr in place with a quicksort whose partition step
+// avoids branch mispredictions (Edelkamp and Weiß's BlockQuicksort).
+//
+// A normal partition branches on every comparison, and on random data the
+// processor guesses wrong half the time. The block partition instead scans
+// a block of items, writing the offset of each one on the wrong side of the
+// pivot into a buffer and advancing the buffer's length by the comparison's
+// result (0 or 1). That loop has no data-dependent branches. Pairs of
+// misplaced items from the two ends are then swapped using the buffers.
+//
+// It is not stable and runs in O(n log n) time in the worst case.
+func BlockQuicksort[T Ordered](arr []T) {
+	// Compare with < directly: Go can't inline a less function passed as a
+	// value, and the call's own branch would defeat the purpose.
+	scanners := block_scanners[T]{left: scan_left_ordered[T], right: scan_right_ordered[T]}
+	block_quicksort_with(arr, Less[T], scanners, nil)
+}
+
+// BlockQuicksortFunc is like BlockQuicksort but orders the items using less.
+func BlockQuicksortFunc[T any](arr []T, less func(a, b T) bool) {
+	block_quicksort(arr, less, nil)
+}
+
+func block_quicksort[T any](arr []T, less func(a, b T) bool, s *stats.Stats) {
+	scanners := block_scanners[T]{
+		left: func(block []T, pivot T, offsets *block_offsets) int {
+			num := 0
+			for i := range block {
+				offsets[num] = uint8(i)
+				num += branchless.B2i(!less(block[i], pivot))
+			}
+			return num
+		},
+		right: func(block []T, pivot T, offsets *block_offsets) int {
+			num := 0
+			last := len(block) - 1
+			for i := range block {
+				offsets[num] = uint8(i)
+				num += branchless.B2i(!less(pivot, block[last-i]))
+			}
+			return num
+		},
+	}
+	block_quicksort_with(arr, less, scanners, s)
+}
+
+func block_quicksort_with[T any](arr []T, less func(a, b T) bool, scanners block_scanners[T], s *stats.Stats) {
+	depth_limit := 2 * log2(len(arr))
+	block_introsort(arr, less, scanners, 1, depth_limit, s)
+}
+
+func block_introsort[T any](arr []T, less func(a, b T) bool, scanners block_scanners[T], depth, depth_limit int, s *stats.Stats) {
+	s.Call(depth)
+
+	for len(arr) > insertion_sort_cutoff {
+		if depth_limit == 0 {
+			heap_sort(arr, less, s)
+			return
+		}
+		depth_limit--
+
+		p := block_partition(arr, less, scanners, s)
+
+		// Recurse into the smaller side and loop on the larger one.
+		if p < len(arr)-p {
+			block_introsort(arr[:p], less, scanners, depth+1, depth_limit, s)
+			arr = arr[p+1:]
+		} else {
+			block_introsort(arr[p+1:], less, scanners, depth+1, depth_limit, s)
+			arr = arr[:p]
+		}
+	}
+
+	insertion_sort(arr, less, s)
+}
+
+// block_partition rearranges arr around a pivot and returns the pivot's
+// final index. Items before it are not greater than the pivot and items after
+// it are not less. Items equal to the pivot may end up on either side, which
+// keeps the split even when there are many duplicates.
+func block_partition[T any](arr []T, less func(a, b T) bool, scanners block_scanners[T], s *stats.Stats) int {
+	// Park the pivot at the front.
+	// Block quicksort doesn't report its steps, so choose_pivot gets no Stats.
+	p := choose_pivot(arr, 0, less, nil)
+	arr[0], arr[p] = arr[p], arr[0]
+	s.Swap()
+	pivot := arr[0]
+
+	// Everything before l belongs on the left and everything after r on the right.
+	l, r := 1, len(arr)-1
+	var offsets_l, offsets_r block_offsets
+	start_l, num_l, start_r, num_r := 0, 0, 0, 0
+
+	// Work a block at a time from each end while the blocks can't overlap.
+	// A block whose misplaced items haven't all been swapped is kept for the
+	// next round without being scanned again.
+	for r-l+1 >= 2*partition_block_size {
+		if num_l == 0 {
+			start_l = 0
+			num_l = scanners.left(arr[l:l+partition_block_size], pivot, &offsets_l)
+		}
+		if num_r == 0 {
+			start_r = 0
+			num_r = scanners.right(arr[r+1-partition_block_size:r+1], pivot, &offsets_r)
+		}
+
+		num := num_l
+		if num_r < num {
+			num = num_r
+		}
+		for k := 0; k < num; k++ {
+			i := l + int(offsets_l[start_l+k])
+			j := r - int(offsets_r[start_r+k])
+			arr[i], arr[j] = arr[j], arr[i]
+			s.Swap()
+		}
+		num_l -= num
+		num_r -= num
+		start_l += num
+		start_r += num
+
+		if num_l == 0 {
+			l += partition_block_size
+		}
+		if num_r == 0 {
+			r -= partition_block_size
+		}
+	}
+
+	// Finish the few items left in the middle with a Hoare partition.
+	for {
+		for l <= r && less(arr[l], pivot) {
+			l++
+		}
+		for l <= r && less(pivot, arr[r]) {
+			r--
+		}
+		if l >= r {
+			break
+		}
+		arr[l], arr[r] = arr[r], arr[l]
+		s.Swap()
+		l++
+		r--
+	}
+
+	// arr[1:l] holds items no greater than the pivot and arr[l:] items no
+	// less, so the pivot goes at l-1.
+	arr[0], arr[l-1] = arr[l-1], arr[0]
+	s.Swap()
+	return l - 1
+}
+
+func scan_left_ordered[T Ordered](block []T, pivot T, offsets *block_offsets) int {
+	num := 0
+	for i := range block {
+		offsets[num] = uint8(i)
+		num += branchless.B2i(!(block[i] < pivot))
+	}
+	return num
+}
+
+func scan_right_ordered[T Ordered](block []T, pivot T, offsets *block_offsets) int {
+	num := 0
+	last := len(block) - 1
+	for i := range block {
+		offsets[num] = uint8(i)
+		num += branchless.B2i(!(pivot < block[last-i]))
+	}
+	return num
+}
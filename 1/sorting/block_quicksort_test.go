@@ -0,0 +1,38 @@
+package sorting
+
+import (
+	"testing"
+
+	"example.com/m/v2/datagen"
+	"example.com/m/v2/internal/testutil"
+)
+
+// TestBlockQuicksortLarge sorts slices long enough for the block partition
+// to run, with few distinct values some of the time.
+func TestBlockQuicksortLarge(t *testing.T) {
+	testutil.RunRounds(t, func(r *datagen.Generator, max_len int) error {
+		n := r.Intn(max_len*50 + 1)
+		var arr []int
+		if r.Intn(2) == 0 {
+			arr = r.FewUnique(n, r.Intn(10)+1)
+		} else {
+			arr = r.Uniform(n, n+1)
+		}
+		if err := check_int_sort(arr, BlockQuicksort[int]); err != nil {
+			return err
+		}
+		return check_int_sort(arr, func(arr []int) { BlockQuicksortFunc(arr, Less[int]) })
+	})
+}
+
+// BenchmarkBlockQuicksort compares block quicksort with quicksort on inputs
+// from 8 KiB, which fits in L1, to 32 MiB, which is larger than most L3 caches.
+func BenchmarkBlockQuicksort(b *testing.B) {
+	sizes := []int{1 << 10, 1 << 14, 1 << 18, 1 << 22}
+	b.Run("quicksort", func(b *testing.B) {
+		benchmark_int_sort(b, sizes, Quicksort[int])
+	})
+	b.Run("block_quicksort", func(b *testing.B) {
+		benchmark_int_sort(b, sizes, BlockQuicksort[int])
+	})
+}
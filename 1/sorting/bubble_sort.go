@@ -0,0 +1,34 @@
+package sorting
+
+import "example.com/m/v2/stats"
+
+// BubbleSort sorts arr in place.
+func BubbleSort[T Ordered](arr []T) {
+	BubbleSortFunc(arr, Less[T])
+}
+
+// BubbleSortFunc sorts arr in place using less to order the items.
+func BubbleSortFunc[T any](arr []T, less func(a, b T) bool) {
+	bubble_sort(arr, less, nil)
+}
+
+func bubble_sort[T any](arr []T, less func(a, b T) bool, s *stats.Stats) {
+	// We require at most len(arr)-1 passes.
+	for i := 0; i < len(arr)-1; i++ {
+		// The last i elements are in their final positions by this point.
+		swapped := false
+		for j := 0; j < len(arr)-1-i; j++ {
+			s.TraceCompare(j+1, j)
+			if less(arr[j+1], arr[j]) {
+				arr[j], arr[j+1] = arr[j+1], arr[j]
+				s.SwapAt(j, j+1)
+				swapped = true
+			}
+		}
+		if !swapped {
+			// The elements are already sorted.
+			break
+		}
+		s.TraceBoundary(len(arr) - 1 - i)
+	}
+}
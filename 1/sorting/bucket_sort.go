@@ -0,0 +1,99 @@
+package sorting
+
+import (
+	"math"
+
+	"example.com/m/v2/stats"
+)
+
+// Buckets holding more items than this are sorted with merge sort instead of
+// insertion sort. That only happens when the keys are far from uniform, and
+// it keeps the worst case at O(n log n).
+const bucket_fallback_cutoff = 32
+
+// LessFloat64 orders floats totally: NaN first, then -Inf, the finite values
+// in numerical order and +Inf last. -0 and +0 tie.
+func LessFloat64(a, b float64) bool {
+	if math.IsNaN(a) {
+		return !math.IsNaN(b)
+	}
+	return a < b
+}
+
+// BucketSort sorts arr in place under the order of LessFloat64.
+//
+// It spreads the finite values over one bucket per item, evenly across the
+// range from the smallest to the largest, and insertion sorts each bucket.
+// When the values are roughly uniform in their range each bucket holds about
+// one item and the sort takes O(n) time. NaNs and infinities get buckets of
+// their own at the ends.
+func BucketSort(arr []float64) {
+	BucketSortFunc(arr, func(v float64) float64 { return v })
+}
+
+// BucketSortFunc sorts arr in place by key like BucketSort. It is stable:
+// items with equal keys keep their original order.
+func BucketSortFunc[T any](arr []T, key func(T) float64) {
+	bucket_sort(arr, key, nil)
+}
+
+// BucketSortStats is like BucketSort but records the work it does in s.
+func BucketSortStats(arr []float64, s *stats.Stats) {
+	bucket_sort(arr, func(v float64) float64 { return v }, s)
+}
+
+func bucket_sort[T any](arr []T, key func(T) float64, s *stats.Stats) {
+	n := len(arr)
+	if n <= 1 {
+		return
+	}
+
+	// Find the range of the finite keys. Halving everything keeps hi - lo
+	// from overflowing when the keys span most of the float64 range.
+	lo, hi := math.Inf(1), math.Inf(-1)
+	for _, v := range arr {
+		if k := key(v); !math.IsNaN(k) && !math.IsInf(k, 0) {
+			lo = math.Min(lo, k/2)
+			hi = math.Max(hi, k/2)
+		}
+	}
+	width := hi - lo
+
+	// Bucket 0 holds NaNs, 1 holds -Inf, 2 to n+1 hold the finite keys and
+	// n+2 holds +Inf.
+	bucket := func(v T) int {
+		k := key(v)
+		switch {
+		case math.IsNaN(k):
+			return 0
+		case math.IsInf(k, -1):
+			return 1
+		case math.IsInf(k, 1):
+			return n + 2
+		case width == 0:
+			return 2
+		}
+		i := int((k/2 - lo) / width * float64(n))
+		if i >= n {
+			// The largest key lands just past the last bucket.
+			i = n - 1
+		}
+		return i + 2
+	}
+
+	buf := make([]T, n)
+	s.Alloc(n)
+	starts := stable_place(arr, buf, n+3, bucket)
+	copy(arr, buf)
+	s.Write(2 * n)
+
+	less := stats.CountLess(s, func(a, b T) bool { return key(a) < key(b) })
+	for b := 2; b < n+2; b++ {
+		part := arr[starts[b]:starts[b+1]]
+		if len(part) > bucket_fallback_cutoff {
+			merge_sort_top_down(part, less, s)
+		} else {
+			insertion_sort(part, less, s)
+		}
+	}
+}
@@ -0,0 +1,54 @@
+package sorting
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"testing"
+
+	"example.com/m/v2/datagen"
+	"example.com/m/v2/internal/testutil"
+)
+
+// TestBucketSort compares BucketSort with a stable sort under LessFloat64 on
+// floats that include NaNs, infinities, signed zeros and clusters that
+// overflow single buckets.
+func TestBucketSort(t *testing.T) {
+	special := []float64{math.NaN(), math.Inf(-1), math.Inf(1), 0, math.Copysign(0, -1), math.MaxFloat64, -math.MaxFloat64}
+	testutil.RunRounds(t, func(r *datagen.Generator, max_len int) error {
+		arr := make([]float64, r.Intn(max_len+1))
+		for i := range arr {
+			switch r.Intn(4) {
+			case 0:
+				arr[i] = special[r.Intn(len(special))]
+			case 1:
+				// A tight cluster that lands in one bucket.
+				arr[i] = 1 + r.Float64()*1e-9
+			default:
+				arr[i] = r.NormFloat64() * 100
+			}
+		}
+
+		want := append([]float64(nil), arr...)
+		sort.SliceStable(want, func(i, j int) bool { return LessFloat64(want[i], want[j]) })
+		got := append([]float64(nil), arr...)
+		BucketSort(got)
+		for i := range want {
+			if math.Float64bits(got[i]) != math.Float64bits(want[i]) {
+				return fmt.Errorf("BucketSort(%v) = %v, want %v", arr, got, want)
+			}
+		}
+		return nil
+	})
+}
+
+// TestBucketSortIsStable sorts customers by a float key with few distinct
+// values and checks that ties keep their order.
+func TestBucketSortIsStable(t *testing.T) {
+	testutil.RunRounds(t, func(r *datagen.Generator, max_len int) error {
+		arr, _ := random_customers(r, max_len)
+		return check_stable_sort(arr, func(arr []customer) {
+			BucketSortFunc(arr, func(c customer) float64 { return float64(c.NumPurchases) / 3 })
+		})
+	})
+}
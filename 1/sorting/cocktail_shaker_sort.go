@@ -0,0 +1,47 @@
+package sorting
+
+import "example.com/m/v2/stats"
+
+// CocktailShakerSort sorts arr in place with a bubble sort that alternates
+// between passes up and down the slice, so small items near the end move
+// to the front quickly instead of one place per pass.
+func CocktailShakerSort[T Ordered](arr []T) {
+	CocktailShakerSortFunc(arr, Less[T])
+}
+
+// CocktailShakerSortFunc sorts arr in place using less to order the items.
+func CocktailShakerSortFunc[T any](arr []T, less func(a, b T) bool) {
+	cocktail_shaker_sort(arr, less, nil)
+}
+
+func cocktail_shaker_sort[T any](arr []T, less func(a, b T) bool, s *stats.Stats) {
+	// The items before lo and after hi are in their final positions.
+	lo, hi := 0, len(arr)-1
+	for lo < hi {
+		// Bubble the largest item up to hi. Everything after the last swap
+		// is already in place.
+		last_swap := lo
+		for j := lo; j < hi; j++ {
+			s.TraceCompare(j+1, j)
+			if less(arr[j+1], arr[j]) {
+				arr[j], arr[j+1] = arr[j+1], arr[j]
+				s.SwapAt(j, j+1)
+				last_swap = j
+			}
+		}
+		hi = last_swap
+		s.TraceBoundary(hi + 1)
+
+		// Bubble the smallest item down to lo.
+		last_swap = hi
+		for j := hi; j > lo; j-- {
+			s.TraceCompare(j, j-1)
+			if less(arr[j], arr[j-1]) {
+				arr[j], arr[j-1] = arr[j-1], arr[j]
+				s.SwapAt(j, j-1)
+				last_swap = j
+			}
+		}
+		lo = last_swap
+	}
+}
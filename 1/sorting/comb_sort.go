@@ -0,0 +1,42 @@
+package sorting
+
+import "example.com/m/v2/stats"
+
+// CombSort sorts arr in place with a bubble sort that first compares items
+// far apart, shrinking the gap by a factor of about 1.3 each pass. That moves
+// small items near the end (the "turtles" that slow bubble sort down) most of
+// the way in a few passes.
+func CombSort[T Ordered](arr []T) {
+	CombSortFunc(arr, Less[T])
+}
+
+// CombSortFunc sorts arr in place using less to order the items.
+func CombSortFunc[T any](arr []T, less func(a, b T) bool) {
+	comb_sort(arr, less, nil)
+}
+
+func comb_sort[T any](arr []T, less func(a, b T) bool, s *stats.Stats) {
+	gap := len(arr)
+	for swapped := true; gap > 1 || swapped; {
+		// Shrink the gap by 1.3 using integer arithmetic.
+		gap = gap * 10 / 13
+		if gap == 9 || gap == 10 {
+			// The "rule of 11" avoids a run of gaps that leaves turtles behind.
+			gap = 11
+		}
+		if gap < 1 {
+			gap = 1
+		}
+
+		// Once the gap is 1 this is bubble sort, which runs until a pass makes no swaps.
+		swapped = false
+		for i := 0; i+gap < len(arr); i++ {
+			s.TraceCompare(i+gap, i)
+			if less(arr[i+gap], arr[i]) {
+				arr[i], arr[i+gap] = arr[i+gap], arr[i]
+				s.SwapAt(i, i+gap)
+				swapped = true
+			}
+		}
+	}
+}
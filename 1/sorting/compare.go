@@ -0,0 +1,61 @@
+package sorting
+
+// Comparator orders two items. It returns a negative number if a comes
+// before b, a positive number if a comes after b, and zero if they tie.
+//
+// Comparators can be chained to sort records by several fields:
+//
+//	by_purchases := sorting.By(func(c Customer) int { return c.num_purchases })
+//	by_id := sorting.By(func(c Customer) string { return c.id })
+//	sorting.TimsortFunc(customers, by_purchases.Reverse().Then(by_id).Less)
+type Comparator[T any] func(a, b T) int
+
+// By returns a Comparator that orders items by key, smallest first.
+func By[T any, K Ordered](key func(T) K) Comparator[T] {
+	return func(a, b T) int {
+		ka, kb := key(a), key(b)
+		if ka < kb {
+			return -1
+		}
+		if kb < ka {
+			return 1
+		}
+		return 0
+	}
+}
+
+// Reverse returns a Comparator that orders items the other way round.
+// Items that tie under c still tie.
+func (c Comparator[T]) Reverse() Comparator[T] {
+	return func(a, b T) int {
+		return c(b, a)
+	}
+}
+
+// Then returns a Comparator that orders items by c and breaks ties with next.
+func (c Comparator[T]) Then(next Comparator[T]) Comparator[T] {
+	return func(a, b T) int {
+		if result := c(a, b); result != 0 {
+			return result
+		}
+		return next(a, b)
+	}
+}
+
+// Chain returns a Comparator that tries each of comparators in turn until
+// one of them doesn't tie. With no comparators every pair of items ties.
+func Chain[T any](comparators ...Comparator[T]) Comparator[T] {
+	return func(a, b T) int {
+		for _, c := range comparators {
+			if result := c(a, b); result != 0 {
+				return result
+			}
+		}
+		return 0
+	}
+}
+
+// Less reports whether a comes before b, so c.Less can be passed to the Func sorts.
+func (c Comparator[T]) Less(a, b T) bool {
+	return c(a, b) < 0
+}
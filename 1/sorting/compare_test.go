@@ -0,0 +1,41 @@
+package sorting
+
+import (
+	"fmt"
+	"sort"
+	"testing"
+
+	"example.com/m/v2/datagen"
+	"example.com/m/v2/internal/testutil"
+)
+
+// TestComparatorChains sorts customers by purchases, most first, then by id
+// and compares the result with sort.SliceStable.
+func TestComparatorChains(t *testing.T) {
+	testutil.RunRounds(t, func(r *datagen.Generator, max_len int) error {
+		arr, _ := random_customers(r, max_len)
+		// Give some customers the same id so the position breaks the final ties.
+		for i := range arr {
+			arr[i].ID = fmt.Sprintf("C%d", r.Intn(5))
+		}
+
+		by_purchases := By(func(c customer) int { return c.NumPurchases })
+		by_id := By(func(c customer) string { return c.ID })
+		order := by_purchases.Reverse().Then(by_id)
+
+		want := append([]customer(nil), arr...)
+		sort.SliceStable(want, func(i, j int) bool { return order.Less(want[i], want[j]) })
+
+		for _, name := range []string{"merge_sort", "merge_sort_bottom_up", "timsort"} {
+			sorter, _ := ByName[customer](name)
+			got := append([]customer(nil), arr...)
+			sorter.Sort(got, order.Less)
+			for i := range got {
+				if got[i] != want[i] {
+					return fmt.Errorf("%s put %v at %d, want %v", name, got[i], i, want[i])
+				}
+			}
+		}
+		return nil
+	})
+}
@@ -0,0 +1,53 @@
+package sorting
+
+import "example.com/m/v2/stats"
+
+// CountingSort returns a sorted copy of arr, ordering the items by key.
+// Every key must be an integer in the range [min, max). The sort is stable:
+// items with equal keys keep their original order.
+func CountingSort[T any](arr []T, min, max int, key func(T) int) []T {
+	return counting_sort(arr, min, max, key, nil)
+}
+
+// CountingSortStats is like CountingSort but records the work it does in s.
+func CountingSortStats[T any](arr []T, min, max int, key func(T) int, s *stats.Stats) []T {
+	return counting_sort(arr, min, max, key, s)
+}
+
+func counting_sort[T any](arr []T, min, max int, key func(T) int, s *stats.Stats) []T {
+	sorted := make([]T, len(arr))
+	s.Alloc(len(arr))
+	stable_place(arr, sorted, max-min, func(v T) int { return key(v) - min })
+	s.Write(len(arr))
+	return sorted
+}
+
+// stable_place copies src into dst grouped by bucket, keeping the items in each
+// bucket in their original order. Every bucket(v) must be in [0, num_buckets).
+// It returns where each bucket starts in dst, followed by len(dst).
+//
+// This is the placement step shared by counting sort and the radix sorts.
+func stable_place[T any](src, dst []T, num_buckets int, bucket func(T) int) []int {
+	starts := make([]int, num_buckets+1)
+
+	// Count the items in each bucket, one slot to the right.
+	for _, v := range src {
+		starts[bucket(v)+1] += 1
+	}
+
+	// Turn the counts into the index where each bucket starts.
+	for i := 1; i <= num_buckets; i++ {
+		starts[i] += starts[i-1]
+	}
+
+	// Copy each item into the next free slot of its bucket.
+	next := make([]int, num_buckets)
+	copy(next, starts)
+	for _, v := range src {
+		b := bucket(v)
+		dst[next[b]] = v
+		next[b] += 1
+	}
+
+	return starts
+}
@@ -0,0 +1,34 @@
+package sorting
+
+import "example.com/m/v2/stats"
+
+// GnomeSort sorts arr in place the way a garden gnome sorts flower pots: it
+// looks at the pot next to it and, if the two are out of order, swaps them
+// and steps back; otherwise it steps forward. It takes O(n²) time and is stable.
+func GnomeSort[T Ordered](arr []T) {
+	GnomeSortFunc(arr, Less[T])
+}
+
+// GnomeSortFunc sorts arr in place using less to order the items.
+func GnomeSortFunc[T any](arr []T, less func(a, b T) bool) {
+	gnome_sort(arr, less, nil)
+}
+
+func gnome_sort[T any](arr []T, less func(a, b T) bool, s *stats.Stats) {
+	pos := 1
+	for pos < len(arr) {
+		// The first item has nothing to its left to compare with.
+		if pos == 0 {
+			pos++
+			continue
+		}
+		s.TraceCompare(pos, pos-1)
+		if !less(arr[pos], arr[pos-1]) {
+			pos++
+		} else {
+			arr[pos], arr[pos-1] = arr[pos-1], arr[pos]
+			s.SwapAt(pos, pos-1)
+			pos--
+		}
+	}
+}
@@ -0,0 +1,30 @@
+package sorting
+
+import (
+	"example.com/m/v2/heap"
+	"example.com/m/v2/stats"
+)
+
+// HeapSort sorts arr in place in O(n log n) time and O(1) extra memory.
+// It is not stable.
+func HeapSort[T Ordered](arr []T) {
+	HeapSortFunc(arr, Less[T])
+}
+
+// HeapSortFunc is like HeapSort but orders the items using less.
+func HeapSortFunc[T any](arr []T, less func(a, b T) bool) {
+	heap_sort(arr, less, nil)
+}
+
+func heap_sort[T any](arr []T, less func(a, b T) bool, s *stats.Stats) {
+	// Build a max-heap in arr itself.
+	h := heap.New(func(a, b T) bool { return less(b, a) })
+	h.Stats = s
+	h.Init(arr)
+
+	// Each Pop moves the largest remaining item to just past the end of the
+	// shrinking heap, which fills arr from the back.
+	for h.Len() > 1 {
+		h.Pop()
+	}
+}
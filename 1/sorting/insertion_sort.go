@@ -0,0 +1,77 @@
+package sorting
+
+import "example.com/m/v2/stats"
+
+// InsertionSort sorts arr in place by moving each item left past the greater
+// items before it. It takes O(n²) time but is fast for short or nearly sorted
+// slices, and it is stable.
+func InsertionSort[T Ordered](arr []T) {
+	InsertionSortFunc(arr, Less[T])
+}
+
+// InsertionSortFunc sorts arr in place using less to order the items.
+func InsertionSortFunc[T any](arr []T, less func(a, b T) bool) {
+	insertion_sort(arr, less, nil)
+}
+
+// BinaryInsertionSort sorts arr in place like InsertionSort but finds where
+// each item goes with a binary search. That cuts the comparisons to
+// O(n log n), although the items still have to be moved one place at a time.
+// It is stable.
+func BinaryInsertionSort[T Ordered](arr []T) {
+	BinaryInsertionSortFunc(arr, Less[T])
+}
+
+// BinaryInsertionSortFunc sorts arr in place using less to order the items.
+func BinaryInsertionSortFunc[T any](arr []T, less func(a, b T) bool) {
+	binary_insertion_sort(arr, 1, less, nil)
+}
+
+// insertion_sort sorts arr in place. It is fast for short or nearly sorted slices.
+func insertion_sort[T any](arr []T, less func(a, b T) bool, s *stats.Stats) {
+	// Checking for a Tracer once keeps the loop fast when there isn't one.
+	tracer := s.GetTracer()
+	for i := 1; i < len(arr); i++ {
+		for j := i; j > 0; j-- {
+			if tracer != nil {
+				tracer.Compare(j, j-1)
+			}
+			if !less(arr[j], arr[j-1]) {
+				break
+			}
+			arr[j], arr[j-1] = arr[j-1], arr[j]
+			s.Swap()
+			if tracer != nil {
+				tracer.Swap(j, j-1)
+			}
+		}
+	}
+}
+
+// binary_insertion_sort sorts arr in place given that arr[:sorted] is already sorted.
+// It finds where each item goes with a binary search, which saves comparisons
+// but not moves. Items that tie are inserted after each other, keeping it stable.
+func binary_insertion_sort[T any](arr []T, sorted int, less func(a, b T) bool, s *stats.Stats) {
+	if sorted < 1 {
+		sorted = 1
+	}
+	for i := sorted; i < len(arr); i++ {
+		item := arr[i]
+
+		// Find the first position whose item is greater than this one.
+		lo, hi := 0, i
+		for lo < hi {
+			mid := lo + (hi-lo)/2
+			if less(item, arr[mid]) {
+				hi = mid
+			} else {
+				lo = mid + 1
+			}
+		}
+
+		// Shift the greater items up and drop this one into the gap.
+		copy(arr[lo+1:i+1], arr[lo:i])
+		arr[lo] = item
+		s.Write(i - lo + 1)
+	}
+}
@@ -0,0 +1,123 @@
+package sorting
+
+import "example.com/m/v2/stats"
+
+// MergeSort sorts arr in place. It is stable and uses O(n) extra memory.
+func MergeSort[T Ordered](arr []T) {
+	MergeSortFunc(arr, Less[T])
+}
+
+// MergeSortFunc sorts arr in place using less to order the items.
+// Items that compare equal keep their original order.
+func MergeSortFunc[T any](arr []T, less func(a, b T) bool) {
+	merge_sort_top_down(arr, less, nil)
+}
+
+func merge_sort_top_down[T any](arr []T, less func(a, b T) bool, s *stats.Stats) {
+	buf := make([]T, len(arr))
+	s.Alloc(len(buf))
+	merge_sort(arr, buf, less, 1, s)
+}
+
+// merge_sort sorts arr using buf, which must be the same length, as scratch space.
+func merge_sort[T any](arr, buf []T, less func(a, b T) bool, depth int, s *stats.Stats) {
+	s.Call(depth)
+
+	if len(arr) <= insertion_sort_cutoff {
+		insertion_sort(arr, less, s)
+		return
+	}
+
+	// Sort the two halves.
+	mid := len(arr) / 2
+	merge_sort(arr[:mid], buf[:mid], less, depth+1, s)
+	merge_sort(arr[mid:], buf[mid:], less, depth+1, s)
+
+	merge_halves(arr, buf, mid, less, s)
+}
+
+// MergeSortBottomUp sorts arr in place. It is stable and uses O(n) extra memory.
+//
+// Instead of recursing it sorts short blocks with insertion sort and then
+// merges neighbouring blocks of width 1, 2, 4, ... times the block size.
+func MergeSortBottomUp[T Ordered](arr []T) {
+	MergeSortBottomUpFunc(arr, Less[T])
+}
+
+// MergeSortBottomUpFunc is like MergeSortBottomUp but orders the items using less.
+func MergeSortBottomUpFunc[T any](arr []T, less func(a, b T) bool) {
+	merge_sort_bottom_up(arr, less, nil)
+}
+
+func merge_sort_bottom_up[T any](arr []T, less func(a, b T) bool, s *stats.Stats) {
+	n := len(arr)
+
+	// Sort the blocks.
+	for lo := 0; lo < n; lo += insertion_sort_cutoff {
+		hi := lo + insertion_sort_cutoff
+		if hi > n {
+			hi = n
+		}
+		insertion_sort(arr[lo:hi], less, s)
+	}
+	if n <= insertion_sort_cutoff {
+		return
+	}
+
+	// Merge pairs of runs from src into dst, then swap their roles.
+	buf := make([]T, n)
+	s.Alloc(n)
+	src, dst := arr, buf
+	for width := insertion_sort_cutoff; width < n; width *= 2 {
+		for lo := 0; lo < n; lo += 2 * width {
+			mid, hi := lo+width, lo+2*width
+			if mid > n {
+				mid = n
+			}
+			if hi > n {
+				hi = n
+			}
+			merge(src[lo:mid], src[mid:hi], dst[lo:hi], less, s)
+		}
+		src, dst = dst, src
+	}
+
+	// The last pass may have left the result in buf.
+	if &src[0] != &arr[0] {
+		copy(arr, src)
+		s.Write(n)
+	}
+}
+
+// merge_halves merges the sorted runs arr[:mid] and arr[mid:] using buf as scratch space.
+func merge_halves[T any](arr, buf []T, mid int, less func(a, b T) bool, s *stats.Stats) {
+	// The halves are already in order.
+	if !less(arr[mid], arr[mid-1]) {
+		return
+	}
+
+	copy(buf, arr)
+	s.Write(len(arr))
+	merge(buf[:mid], buf[mid:], arr, less, s)
+}
+
+// merge merges the sorted slices left and right into out.
+// Ties are taken from left first, which keeps the merge stable.
+func merge[T any](left, right, out []T, less func(a, b T) bool, s *stats.Stats) {
+	i, j, k := 0, 0, 0
+	for i < len(left) && j < len(right) {
+		if less(right[j], left[i]) {
+			out[k] = right[j]
+			j++
+		} else {
+			out[k] = left[i]
+			i++
+		}
+		k++
+	}
+
+	// Copy whichever side has items left over.
+	k += copy(out[k:], left[i:])
+	copy(out[k:], right[j:])
+	s.Write(len(out))
+}
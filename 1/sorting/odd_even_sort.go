@@ -0,0 +1,104 @@
+package sorting
+
+import (
+	"runtime"
+	"sync"
+	"sync/atomic"
+
+	"example.com/m/v2/stats"
+)
+
+// Slices shorter than this are sorted by ParallelOddEvenSort on one goroutine.
+const odd_even_parallel_cutoff = 1 << 10
+
+// OddEvenSort sorts arr in place with odd-even transposition sort. It
+// alternates between comparing every even-odd pair (0-1, 2-3, ...) and every
+// odd-even pair (1-2, 3-4, ...), swapping the pairs that are out of order,
+// until neither phase swaps anything. It takes O(n²) time and is stable.
+//
+// The pairs in a phase don't overlap, so they can all be compared at once;
+// see ParallelOddEvenSort.
+func OddEvenSort[T Ordered](arr []T) {
+	OddEvenSortFunc(arr, Less[T])
+}
+
+// OddEvenSortFunc sorts arr in place using less to order the items.
+func OddEvenSortFunc[T any](arr []T, less func(a, b T) bool) {
+	odd_even_sort(arr, less, nil)
+}
+
+func odd_even_sort[T any](arr []T, less func(a, b T) bool, s *stats.Stats) {
+	for sorted := false; !sorted; {
+		sorted = true
+		for start := 0; start <= 1; start++ {
+			if odd_even_phase(arr, start, less, s) {
+				sorted = false
+			}
+		}
+	}
+}
+
+// odd_even_phase compares the pairs (start, start+1), (start+2, start+3), ...
+// and swaps those that are out of order. It reports whether it swapped any.
+func odd_even_phase[T any](arr []T, start int, less func(a, b T) bool, s *stats.Stats) bool {
+	swapped := false
+	for i := start; i+1 < len(arr); i += 2 {
+		if less(arr[i+1], arr[i]) {
+			arr[i], arr[i+1] = arr[i+1], arr[i]
+			s.Swap()
+			swapped = true
+		}
+	}
+	return swapped
+}
+
+// ParallelOddEvenSort sorts arr in place like OddEvenSort but splits the
+// pairs of each phase among parallelism goroutines, which wait for each
+// other before the next phase. If parallelism is not positive,
+// runtime.GOMAXPROCS(0) is used.
+func ParallelOddEvenSort[T Ordered](arr []T, parallelism int) {
+	ParallelOddEvenSortFunc(arr, Less[T], parallelism)
+}
+
+// ParallelOddEvenSortFunc is like ParallelOddEvenSort but orders the items using less.
+func ParallelOddEvenSortFunc[T any](arr []T, less func(a, b T) bool, parallelism int) {
+	parallel_odd_even_sort(arr, less, parallelism, nil)
+}
+
+func parallel_odd_even_sort[T any](arr []T, less func(a, b T) bool, parallelism int, s *stats.Stats) {
+	if parallelism <= 0 {
+		parallelism = runtime.GOMAXPROCS(0)
+	}
+	if parallelism == 1 || len(arr) < odd_even_parallel_cutoff {
+		odd_even_sort(arr, less, s)
+		return
+	}
+
+	// Each worker gets an even-length chunk so that no pair straddles two chunks.
+	chunk := (len(arr)/parallelism + 1) &^ 1
+	for sorted := false; !sorted; {
+		sorted = true
+		for start := 0; start <= 1; start++ {
+			var swapped atomic.Bool
+			var wg sync.WaitGroup
+			for lo := 0; lo < len(arr); lo += chunk {
+				// A chunk's last pair reaches one item into the next chunk in the odd phase.
+				hi := lo + chunk + start
+				if hi > len(arr) {
+					hi = len(arr)
+				}
+				wg.Add(1)
+				go func(part []T) {
+					defer wg.Done()
+					if odd_even_phase(part, start, less, s) {
+						swapped.Store(true)
+					}
+				}(arr[lo:hi])
+			}
+			wg.Wait()
+			if swapped.Load() {
+				sorted = false
+			}
+		}
+	}
+}
@@ -0,0 +1,25 @@
+// Package sorting holds the sorting algorithms used by the chapter 1 demos.
+//
+// Every comparison sort comes in two flavours: a plain version for ordered
+// types such as int or string, and a Func version that sorts any slice using
+// a caller-supplied less function.
+package sorting
+
+// Ordered is satisfied by every type that supports the < operator.
+type Ordered interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64 |
+		~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 | ~uintptr |
+		~float32 | ~float64 |
+		~string
+}
+
+// Signed is satisfied by every signed integer type.
+type Signed interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64
+}
+
+// Less is the natural ordering of an Ordered type. It can be passed to
+// the Func variants of the sorts.
+func Less[T Ordered](a, b T) bool {
+	return a < b
+}
@@ -0,0 +1,116 @@
+package sorting
+
+import (
+	"runtime"
+
+	"example.com/m/v2/stats"
+)
+
+// Slices shorter than this are not worth handing to another goroutine.
+const parallel_cutoff = 1 << 13
+
+// pool hands work to a bounded number of extra goroutines.
+type pool struct {
+	// One token for every goroutine that is currently running.
+	tokens chan struct{}
+}
+
+// make_pool makes a pool that runs up to parallelism goroutines, counting the caller's.
+// If parallelism is not positive, runtime.GOMAXPROCS(0) is used.
+func make_pool(parallelism int) *pool {
+	if parallelism <= 0 {
+		parallelism = runtime.GOMAXPROCS(0)
+	}
+	return &pool{tokens: make(chan struct{}, parallelism-1)}
+}
+
+// spawn runs f on a new goroutine if a worker is free and otherwise on the caller's.
+// The returned function waits until f has finished.
+func (p *pool) spawn(f func()) (wait func()) {
+	select {
+	case p.tokens <- struct{}{}:
+		done := make(chan struct{})
+		go func() {
+			f()
+			<-p.tokens
+			close(done)
+		}()
+		return func() { <-done }
+	default:
+		f()
+		return func() {}
+	}
+}
+
+// ParallelQuicksort sorts arr in place, sorting the two sides of large partitions
+// on up to parallelism goroutines. If parallelism is not positive,
+// runtime.GOMAXPROCS(0) is used.
+func ParallelQuicksort[T Ordered](arr []T, parallelism int) {
+	ParallelQuicksortFunc(arr, Less[T], parallelism)
+}
+
+// ParallelQuicksortFunc is like ParallelQuicksort but orders the items using less.
+func ParallelQuicksortFunc[T any](arr []T, less func(a, b T) bool, parallelism int) {
+	parallel_quicksort(arr, less, parallelism, nil)
+}
+
+func parallel_quicksort[T any](arr []T, less func(a, b T) bool, parallelism int, s *stats.Stats) {
+	depth_limit := 2 * log2(len(arr))
+	parallel_introsort(arr, 0, less, 1, depth_limit, make_pool(parallelism), s)
+}
+
+// parallel_introsort sorts arr, which starts at index offset of the slice being sorted.
+func parallel_introsort[T any](arr []T, offset int, less func(a, b T) bool, depth, depth_limit int, p *pool, s *stats.Stats) {
+	if len(arr) < parallel_cutoff {
+		introsort(arr, offset, less, depth, depth_limit, s)
+		return
+	}
+	s.Call(depth)
+	if depth_limit == 0 {
+		sort_part(heap_sort[T], arr, offset, less, s)
+		return
+	}
+	depth_limit--
+
+	lt, gt := partition3(arr, offset, less, s)
+
+	// Sort the two sides at the same time.
+	wait := p.spawn(func() { parallel_introsort(arr[:lt], offset, less, depth+1, depth_limit, p, s) })
+	parallel_introsort(arr[gt:], offset+gt, less, depth+1, depth_limit, p, s)
+	wait()
+}
+
+// ParallelMergeSort sorts arr in place, sorting the two halves of large slices
+// on up to parallelism goroutines. If parallelism is not positive,
+// runtime.GOMAXPROCS(0) is used.
+func ParallelMergeSort[T Ordered](arr []T, parallelism int) {
+	ParallelMergeSortFunc(arr, Less[T], parallelism)
+}
+
+// ParallelMergeSortFunc is like ParallelMergeSort but orders the items using less.
+// Items that compare equal keep their original order.
+func ParallelMergeSortFunc[T any](arr []T, less func(a, b T) bool, parallelism int) {
+	parallel_merge_sort(arr, less, parallelism, nil)
+}
+
+func parallel_merge_sort[T any](arr []T, less func(a, b T) bool, parallelism int, s *stats.Stats) {
+	buf := make([]T, len(arr))
+	s.Alloc(len(buf))
+	parallel_merge_sort_halves(arr, buf, less, 1, make_pool(parallelism), s)
+}
+
+func parallel_merge_sort_halves[T any](arr, buf []T, less func(a, b T) bool, depth int, p *pool, s *stats.Stats) {
+	if len(arr) < parallel_cutoff {
+		merge_sort(arr, buf, less, depth, s)
+		return
+	}
+	s.Call(depth)
+
+	// Sort the two halves at the same time.
+	mid := len(arr) / 2
+	wait := p.spawn(func() { parallel_merge_sort_halves(arr[:mid], buf[:mid], less, depth+1, p, s) })
+	parallel_merge_sort_halves(arr[mid:], buf[mid:], less, depth+1, p, s)
+	wait()
+
+	merge_halves(arr, buf, mid, less, s)
+}
@@ -0,0 +1,25 @@
+package sorting
+
+import "testing"
+
+// The parallel sorts only pay off on large inputs, so they are benchmarked
+// against their sequential versions at a million items and more. Run them
+// with -cpu 1,2,4,8 to see how they scale, since they use GOMAXPROCS
+// goroutines.
+var parallel_benchmark_sizes = []int{1 << 20, 1 << 22}
+
+func BenchmarkQuicksort(b *testing.B) {
+	benchmark_int_sort(b, parallel_benchmark_sizes, Quicksort[int])
+}
+
+func BenchmarkParallelQuicksort(b *testing.B) {
+	benchmark_int_sort(b, parallel_benchmark_sizes, func(arr []int) { ParallelQuicksort(arr, 0) })
+}
+
+func BenchmarkMergeSort(b *testing.B) {
+	benchmark_int_sort(b, parallel_benchmark_sizes, MergeSort[int])
+}
+
+func BenchmarkParallelMergeSort(b *testing.B) {
+	benchmark_int_sort(b, parallel_benchmark_sizes, func(arr []int) { ParallelMergeSort(arr, 0) })
+}
@@ -0,0 +1,179 @@
+package sorting
+
+import "example.com/m/v2/stats"
+
+// Presortedness measures how far a slice is from sorted. For sorted input
+// Inversions and MaxDisplacement are 0, Runs is 1 and LongestIncreasing is
+// Items; each measure drifts from that with a different kind of disorder.
+type Presortedness struct {
+	// Items is the length of the slice.
+	Items int `json:"items"`
+	// Inversions is the number of pairs of items that are out of order.
+	// Insertion sort takes O(n + Inversions) time.
+	Inversions int `json:"inversions"`
+	// Runs is the number of maximal non-descending stretches. A natural
+	// merge sort takes O(n log Runs) time.
+	Runs int `json:"runs"`
+	// LongestIncreasing is the length of the longest non-descending
+	// subsequence, so Items-LongestIncreasing items are out of place.
+	LongestIncreasing int `json:"longest_increasing"`
+	// MaxDisplacement is the furthest any item is from where a stable sort
+	// would put it.
+	MaxDisplacement int `json:"max_displacement"`
+	// Sampled is set if the measures were estimated from a sample.
+	Sampled bool `json:"sampled"`
+}
+
+// MeasurePresortedness returns every presortedness measure of arr.
+// It takes O(n log n) time and leaves arr unchanged.
+func MeasurePresortedness[T Ordered](arr []T) Presortedness {
+	return MeasurePresortednessFunc(arr, Less[T])
+}
+
+// MeasurePresortednessFunc is like MeasurePresortedness but orders the items using less.
+func MeasurePresortednessFunc[T any](arr []T, less func(a, b T) bool) Presortedness {
+	return measure_presortedness(arr, less, nil)
+}
+
+func measure_presortedness[T any](arr []T, less func(a, b T) bool, s *stats.Stats) Presortedness {
+	return Presortedness{
+		Items:             len(arr),
+		Inversions:        inversions(arr, less, s),
+		Runs:              RunsFunc(arr, less),
+		LongestIncreasing: LongestIncreasingSubsequenceFunc(arr, less),
+		MaxDisplacement:   max_displacement(arr, less, s),
+	}
+}
+
+// Inversions returns the number of pairs i < j with arr[i] > arr[j], counted
+// in O(n log n) time by merge sorting a copy of arr.
+func Inversions[T Ordered](arr []T) int {
+	return InversionsFunc(arr, Less[T])
+}
+
+// InversionsFunc is like Inversions but orders the items using less.
+func InversionsFunc[T any](arr []T, less func(a, b T) bool) int {
+	return inversions(arr, less, nil)
+}
+
+func inversions[T any](arr []T, less func(a, b T) bool, s *stats.Stats) int {
+	sorted := append([]T(nil), arr...)
+	buf := make([]T, len(arr))
+	s.Alloc(2 * len(arr))
+	return count_inversions(sorted, buf, less, s)
+}
+
+// count_inversions merge sorts arr using buf as scratch space and returns the
+// number of inversions it had.
+func count_inversions[T any](arr, buf []T, less func(a, b T) bool, s *stats.Stats) int {
+	if len(arr) < 2 {
+		return 0
+	}
+	mid := len(arr) / 2
+	count := count_inversions(arr[:mid], buf[:mid], less, s) + count_inversions(arr[mid:], buf[mid:], less, s)
+
+	// When an item from the right half is taken, it is less than every item
+	// left in the left half, and each of those pairs is an inversion.
+	copy(buf, arr)
+	i, j, k := 0, mid, 0
+	for i < mid && j < len(arr) {
+		if less(buf[j], buf[i]) {
+			arr[k] = buf[j]
+			count += mid - i
+			j++
+		} else {
+			arr[k] = buf[i]
+			i++
+		}
+		k++
+	}
+	// Any items left in the right half are already in place.
+	copy(arr[k:], buf[i:mid])
+	s.Write(len(arr))
+	return count
+}
+
+// Runs returns the number of maximal non-descending runs in arr, or 0 if it is
+// empty. Sorted input is one run.
+func Runs[T Ordered](arr []T) int {
+	return RunsFunc(arr, Less[T])
+}
+
+// RunsFunc is like Runs but orders the items using less.
+func RunsFunc[T any](arr []T, less func(a, b T) bool) int {
+	if len(arr) == 0 {
+		return 0
+	}
+	runs := 1
+	for i := 1; i < len(arr); i++ {
+		if less(arr[i], arr[i-1]) {
+			runs++
+		}
+	}
+	return runs
+}
+
+// LongestIncreasingSubsequence returns the length of the longest
+// non-descending subsequence of arr in O(n log n) time.
+func LongestIncreasingSubsequence[T Ordered](arr []T) int {
+	return LongestIncreasingSubsequenceFunc(arr, Less[T])
+}
+
+// LongestIncreasingSubsequenceFunc is like LongestIncreasingSubsequence but
+// orders the items using less.
+func LongestIncreasingSubsequenceFunc[T any](arr []T, less func(a, b T) bool) int {
+	// Patience sorting: tails[k] is the smallest item that ends a
+	// non-descending subsequence of length k+1. It is non-descending itself.
+	var tails []T
+	for _, v := range arr {
+		// Find the first tail greater than v.
+		lo, hi := 0, len(tails)
+		for lo < hi {
+			mid := lo + (hi-lo)/2
+			if less(v, tails[mid]) {
+				hi = mid
+			} else {
+				lo = mid + 1
+			}
+		}
+
+		if lo == len(tails) {
+			tails = append(tails, v)
+		} else {
+			tails[lo] = v
+		}
+	}
+	return len(tails)
+}
+
+// MaxDisplacement returns the largest distance between an item's index in arr
+// and its index after a stable sort, in O(n log n) time.
+func MaxDisplacement[T Ordered](arr []T) int {
+	return MaxDisplacementFunc(arr, Less[T])
+}
+
+// MaxDisplacementFunc is like MaxDisplacement but orders the items using less.
+func MaxDisplacementFunc[T any](arr []T, less func(a, b T) bool) int {
+	return max_displacement(arr, less, nil)
+}
+
+func max_displacement[T any](arr []T, less func(a, b T) bool, s *stats.Stats) int {
+	// Stably sort the indexes by their items.
+	order := make([]int, len(arr))
+	for i := range order {
+		order[i] = i
+	}
+	merge_sort_top_down(order, func(a, b int) bool { return less(arr[a], arr[b]) }, s)
+
+	max := 0
+	for to, from := range order {
+		d := to - from
+		if d < 0 {
+			d = -d
+		}
+		if d > max {
+			max = d
+		}
+	}
+	return max
+}
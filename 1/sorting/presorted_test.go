@@ -0,0 +1,119 @@
+package sorting
+
+import (
+	"fmt"
+	"testing"
+
+	"example.com/m/v2/datagen"
+	"example.com/m/v2/internal/testutil"
+)
+
+func distance(a, b int) int {
+	if a < b {
+		return b - a
+	}
+	return a - b
+}
+
+// TestMeasurePresortedness compares the presortedness measures with
+// quadratic versions that follow their definitions.
+func TestMeasurePresortedness(t *testing.T) {
+	testutil.RunRounds(t, func(r *datagen.Generator, max_len int) error {
+		arr := r.Mixed(max_len)
+		if r.Intn(2) == 0 {
+			// Mostly sorted input is more interesting than random input.
+			arr = r.NearlySorted(len(arr), r.Float64()*0.2)
+		}
+		got := MeasurePresortedness(arr)
+
+		want := Presortedness{Items: len(arr)}
+		for i := range arr {
+			for j := i + 1; j < len(arr); j++ {
+				if arr[j] < arr[i] {
+					want.Inversions++
+				}
+			}
+			if i == 0 || arr[i] < arr[i-1] {
+				want.Runs++
+			}
+		}
+
+		// longest[i] is the longest non-descending subsequence ending at arr[i].
+		longest := make([]int, len(arr))
+		for i := range arr {
+			longest[i] = 1
+			for j := 0; j < i; j++ {
+				if arr[j] <= arr[i] && longest[j]+1 > longest[i] {
+					longest[i] = longest[j] + 1
+				}
+			}
+			if longest[i] > want.LongestIncreasing {
+				want.LongestIncreasing = longest[i]
+			}
+		}
+
+		// An item's stable position is the number of items less than it plus
+		// the number of equal items before it.
+		for i, v := range arr {
+			position := 0
+			for j, w := range arr {
+				if w < v || w == v && j < i {
+					position++
+				}
+			}
+			if d := distance(position, i); d > want.MaxDisplacement {
+				want.MaxDisplacement = d
+			}
+		}
+
+		if got != want {
+			return fmt.Errorf("MeasurePresortedness(%s) = %+v, want %+v", testutil.Describe(arr), got, want)
+		}
+		return nil
+	})
+}
+
+// TestAdaptiveChoice checks that AdaptiveSort takes the obvious choice on
+// inputs whose shape leaves no doubt, and that it sorts them.
+func TestAdaptiveChoice(t *testing.T) {
+	testutil.RunRounds(t, func(r *datagen.Generator, max_len int) error {
+		n := r.Intn(max_len*100) + 1000
+		cases := []struct {
+			arr  []int
+			want string
+		}{
+			{datagen.Sorted(n), "insertion_sort"},
+			{datagen.Sawtooth(n, n/4+1), "timsort"},
+			{r.Uniform(n, n), "quicksort"},
+		}
+		for _, c := range cases {
+			choice := AdaptiveSort(c.arr)
+			if choice.Algorithm != c.want {
+				return fmt.Errorf("AdaptiveSort of %d items chose %s (%s), want %s", n, choice.Algorithm, choice.Reason, c.want)
+			}
+			if !IsSorted(c.arr) {
+				return fmt.Errorf("AdaptiveSort of %d items with %s didn't sort them", n, choice.Algorithm)
+			}
+		}
+		return nil
+	})
+}
+
+// TestAdaptiveSortDisorderBetweenWindows reverses a stretch of a sorted slice
+// that falls between the sampled windows, which the sample doesn't see.
+// Insertion sort would need billions of moves, so it has to give up.
+func TestAdaptiveSortDisorderBetweenWindows(t *testing.T) {
+	n := 200000
+	arr := datagen.Sorted(n)
+	for i, j := 40, (n-adaptive_window_size)/(adaptive_windows-1)-9; i < j; i, j = i+1, j-1 {
+		arr[i], arr[j] = arr[j], arr[i]
+	}
+
+	choice := AdaptiveSort(arr)
+	if choice.Algorithm == "insertion_sort" {
+		t.Fatalf("AdaptiveSort chose insertion sort (%s)", choice.Reason)
+	}
+	if !IsSorted(arr) {
+		t.Fatalf("AdaptiveSort with %s didn't sort the items", choice.Algorithm)
+	}
+}
@@ -0,0 +1,262 @@
+package sorting
+
+import (
+	"math/bits"
+
+	"example.com/m/v2/stats"
+)
+
+// Slices this short are finished off with insertion sort.
+const insertion_sort_cutoff = 12
+
+// Slices at least this long pick their pivot with Tukey's ninther
+// instead of a plain median of three.
+const ninther_cutoff = 40
+
+// log2 returns the number of bits needed to represent n.
+func log2(n int) int {
+	return bits.Len(uint(n))
+}
+
+// Partition rearranges arr around its last item and returns the pivot's final index.
+// Items before the pivot are less than or equal to it, items after are greater.
+func Partition[T Ordered](arr []T) int {
+	return PartitionFunc(arr, Less[T])
+}
+
+// PartitionFunc is like Partition but orders the items using less.
+func PartitionFunc[T any](arr []T, less func(a, b T) bool) int {
+	return lomuto_partition(arr, 0, less, nil)
+}
+
+// lomuto_partition is Partition. arr starts at index offset of the slice
+// being sorted, which is where the steps are reported to s's Tracer.
+func lomuto_partition[T any](arr []T, offset int, less func(a, b T) bool, s *stats.Stats) int {
+	lo := 0
+	hi := len(arr) - 1
+
+	// Choose the last element as the pivot.
+	pivot := arr[hi]
+	s.TracePivot(offset + hi)
+
+	// Temporary pivot index.
+	i := lo - 1
+
+	for j := lo; j < hi; j++ {
+		// If the current element is less than or equal to the pivot.
+		s.TraceCompare(offset+hi, offset+j)
+		if !less(pivot, arr[j]) {
+			// Move the temporary pivot index forward.
+			i = i + 1
+			// Swap the current element with the element at the temporary pivot index.
+			arr[i], arr[j] = arr[j], arr[i]
+			s.SwapAt(offset+i, offset+j)
+			s.TraceBoundary(offset + i + 1)
+		}
+	}
+
+	// Move the pivot element to the correct pivot position (between the smaller and larger elements).
+	i = i + 1
+	arr[i], arr[hi] = arr[hi], arr[i]
+	s.SwapAt(offset+i, offset+hi)
+	s.TracePivot(offset + i)
+
+	// The pivot index.
+	return i
+}
+
+// QuicksortLomuto sorts arr in place with the textbook quicksort that always
+// pivots on the last item. It goes quadratic on sorted or all-equal input.
+func QuicksortLomuto[T Ordered](arr []T) {
+	QuicksortLomutoFunc(arr, Less[T])
+}
+
+// QuicksortLomutoFunc is like QuicksortLomuto but orders the items using less.
+func QuicksortLomutoFunc[T any](arr []T, less func(a, b T) bool) {
+	quicksort_lomuto_top(arr, less, nil)
+}
+
+func quicksort_lomuto_top[T any](arr []T, less func(a, b T) bool, s *stats.Stats) {
+	quicksort_lomuto(arr, 0, less, 1, s)
+}
+
+// quicksort_lomuto sorts arr, which starts at index offset of the slice being sorted.
+func quicksort_lomuto[T any](arr []T, offset int, less func(a, b T) bool, depth int, s *stats.Stats) {
+	s.Call(depth)
+
+	// Slice is so small that it doesn’t need sorting.
+	if len(arr) <= 1 {
+		return
+	}
+	s.TraceRange(offset, offset+len(arr)-1)
+
+	// Partition array and get the pivot index.
+	p := lomuto_partition(arr, offset, less, s)
+
+	// Sort the two partitions.
+	quicksort_lomuto(arr[0:p], offset, less, depth+1, s)
+	quicksort_lomuto(arr[p+1:], offset+p+1, less, depth+1, s)
+}
+
+// Quicksort sorts arr in place.
+//
+// It is an introsort: the pivot is a median of three (or ninther), runs of
+// items equal to the pivot are split off by a three-way partition, short
+// slices are finished with insertion sort and heapsort takes over if the
+// recursion gets too deep, so the worst case is O(n log n).
+func Quicksort[T Ordered](arr []T) {
+	QuicksortFunc(arr, Less[T])
+}
+
+// QuicksortFunc sorts arr in place using less to order the items.
+func QuicksortFunc[T any](arr []T, less func(a, b T) bool) {
+	quicksort(arr, less, nil)
+}
+
+func quicksort[T any](arr []T, less func(a, b T) bool, s *stats.Stats) {
+	// Allow about twice the depth of a perfectly balanced recursion.
+	depth_limit := 2 * log2(len(arr))
+	introsort(arr, 0, less, 1, depth_limit, s)
+}
+
+// introsort sorts arr, which starts at index offset of the slice being sorted.
+func introsort[T any](arr []T, offset int, less func(a, b T) bool, depth, depth_limit int, s *stats.Stats) {
+	s.Call(depth)
+
+	for len(arr) > insertion_sort_cutoff {
+		s.TraceRange(offset, offset+len(arr)-1)
+
+		// The pivots have been bad too often, so give up on quicksort.
+		if depth_limit == 0 {
+			sort_part(heap_sort[T], arr, offset, less, s)
+			return
+		}
+		depth_limit--
+
+		lt, gt := partition3(arr, offset, less, s)
+
+		// Recurse into the smaller side and loop on the larger one
+		// so the stack never holds more than log(n) frames.
+		if lt < len(arr)-gt {
+			introsort(arr[:lt], offset, less, depth+1, depth_limit, s)
+			arr, offset = arr[gt:], offset+gt
+		} else {
+			introsort(arr[gt:], offset+gt, less, depth+1, depth_limit, s)
+			arr = arr[:lt]
+		}
+	}
+
+	if len(arr) > 1 {
+		s.TraceRange(offset, offset+len(arr)-1)
+	}
+	sort_part(insertion_sort[T], arr, offset, less, s)
+}
+
+// partition3 rearranges arr into items less than, equal to and greater than
+// a pivot (the Dutch national flag problem). On return arr[:lt] < pivot,
+// arr[lt:gt] == pivot and arr[gt:] > pivot. arr starts at index offset of
+// the slice being sorted, which is where the steps are reported to s's Tracer.
+func partition3[T any](arr []T, offset int, less func(a, b T) bool, s *stats.Stats) (lt, gt int) {
+	return partition3_around(arr, choose_pivot(arr, offset, less, s), offset, less, s)
+}
+
+// partition3_around is like partition3 but pivots on the item at index p.
+func partition3_around[T any](arr []T, p, offset int, less func(a, b T) bool, s *stats.Stats) (lt, gt int) {
+	if s.GetTracer() != nil {
+		return traced_partition3_around(arr, p, offset, less, s)
+	}
+
+	pivot := arr[p]
+	lt, i, gt := 0, 0, len(arr)
+	for i < gt {
+		if less(arr[i], pivot) {
+			arr[lt], arr[i] = arr[i], arr[lt]
+			s.Swap()
+			lt++
+			i++
+		} else if less(pivot, arr[i]) {
+			gt--
+			arr[i], arr[gt] = arr[gt], arr[i]
+			s.Swap()
+		} else {
+			i++
+		}
+	}
+
+	return lt, gt
+}
+
+// traced_partition3_around is partition3_around for when s has a Tracer. It
+// is kept apart so that the untraced loop doesn't pay for the checks.
+// p follows the pivot item around as it is swapped, so the comparisons can
+// be reported against it.
+func traced_partition3_around[T any](arr []T, p, offset int, less func(a, b T) bool, s *stats.Stats) (lt, gt int) {
+	pivot := arr[p]
+	s.TracePivot(offset + p)
+
+	lt, i, gt := 0, 0, len(arr)
+	for i < gt {
+		s.TraceCompare(offset+i, offset+p)
+		if less(arr[i], pivot) {
+			arr[lt], arr[i] = arr[i], arr[lt]
+			s.SwapAt(offset+lt, offset+i)
+			if p == lt {
+				p = i
+			}
+			lt++
+			i++
+			s.TraceBoundary(offset + lt)
+			continue
+		}
+
+		s.TraceCompare(offset+p, offset+i)
+		if less(pivot, arr[i]) {
+			gt--
+			arr[i], arr[gt] = arr[gt], arr[i]
+			s.SwapAt(offset+i, offset+gt)
+			if p == gt {
+				p = i
+			}
+		} else {
+			i++
+		}
+	}
+
+	return lt, gt
+}
+
+// choose_pivot returns the index of a good pivot for arr. arr starts at index
+// offset of the slice being sorted, which is where the comparisons are
+// reported to s's Tracer.
+func choose_pivot[T any](arr []T, offset int, less func(a, b T) bool, s *stats.Stats) int {
+	lo, mid, hi := 0, len(arr)/2, len(arr)-1
+
+	if len(arr) < ninther_cutoff {
+		return median_of_three(arr, offset, less, s, lo, mid, hi)
+	}
+
+	// Tukey's ninther: the median of the medians of three groups of three.
+	step := len(arr) / 8
+	lo = median_of_three(arr, offset, less, s, lo, lo+step, lo+2*step)
+	mid = median_of_three(arr, offset, less, s, mid-step, mid, mid+step)
+	hi = median_of_three(arr, offset, less, s, hi-2*step, hi-step, hi)
+	return median_of_three(arr, offset, less, s, lo, mid, hi)
+}
+
+// median_of_three returns whichever of the indices a, b and c holds the median value.
+func median_of_three[T any](arr []T, offset int, less func(a, b T) bool, s *stats.Stats, a, b, c int) int {
+	s.TraceCompare(offset+b, offset+a)
+	if less(arr[b], arr[a]) {
+		a, b = b, a
+	}
+	// Now arr[a] <= arr[b].
+	s.TraceCompare(offset+c, offset+b)
+	if less(arr[c], arr[b]) {
+		s.TraceCompare(offset+c, offset+a)
+		if less(arr[c], arr[a]) {
+			return a
+		}
+		return c
+	}
+	return b
+}
@@ -0,0 +1,126 @@
+package sorting
+
+import (
+	"fmt"
+	"testing"
+
+	"example.com/m/v2/datagen"
+	"example.com/m/v2/stats"
+)
+
+// median_of_three_killer returns Musser's permutation of 1..n that drives a
+// quicksort pivoting on the median of the first, middle and last items to
+// quadratic time. n must be a multiple of 4.
+func median_of_three_killer(n int) []int {
+	k := n / 2
+	arr := make([]int, n)
+	for i := 1; i <= k; i++ {
+		if i%2 == 1 {
+			arr[i-1] = i
+			arr[i] = k + i
+		}
+		arr[k+i-1] = 2 * i
+	}
+	return arr
+}
+
+// check_quicksort_stats checks that quicksort's recursion on n items stayed
+// within the depth limit, about twice the balanced depth, and that it made
+// at most c·n·log2(n) comparisons.
+func check_quicksort_stats(n int, s *stats.Stats, c int) error {
+	if max := int64(2*log2(n) + 1); s.MaxDepth > max {
+		return fmt.Errorf("recursed %d deep on %d items, want at most %d", s.MaxDepth, n, max)
+	}
+	if max := int64(c * n * log2(n)); s.Comparisons > max {
+		return fmt.Errorf("made %d comparisons on %d items, want at most %d", s.Comparisons, n, max)
+	}
+	return nil
+}
+
+// TestQuicksortBounds runs quicksort on the inputs that trip up simpler
+// pivot rules.
+func TestQuicksortBounds(t *testing.T) {
+	sorter, _ := ByName[int]("quicksort")
+	for _, n := range []int{1000, 10000, 100000} {
+		inputs := []struct {
+			name string
+			arr  []int
+		}{
+			{"sorted", datagen.Sorted(n)},
+			{"reversed", datagen.Reversed(n)},
+			{"all_equal", make([]int, n)},
+			{"organ_pipe", datagen.OrganPipe(n)},
+			{"median_of_three_killer", median_of_three_killer(n)},
+		}
+		for _, input := range inputs {
+			s := &stats.Stats{}
+			sorter.SortStats(input.arr, Less[int], s)
+			if !IsSorted(input.arr) {
+				t.Errorf("%s: didn't sort %d items", input.name, n)
+			}
+			// Good pivots take about 1.4·n·log2(n) comparisons.
+			if err := check_quicksort_stats(n, s, 2); err != nil {
+				t.Errorf("%s: %v", input.name, err)
+			}
+		}
+	}
+}
+
+// adversary returns McIlroy's adversary for n items: a less function on the
+// indices 0..n-1 that decides the values of the items as the sort compares
+// them, so that every pivot is as bad as it can be, and the values it has
+// decided so far.
+func adversary(n int) (less func(a, b int) bool, values []int) {
+	// Every item starts out as "gas", bigger than any value handed out so
+	// far. Comparing two gas items freezes one of them to the next value,
+	// preferring the one that looks like the pivot.
+	gas := n
+	values = make([]int, n)
+	for i := range values {
+		values[i] = gas
+	}
+	next_value := 0
+	candidate := 0
+	freeze := func(i int) {
+		values[i] = next_value
+		next_value++
+	}
+	less = func(a, b int) bool {
+		if values[a] == gas && values[b] == gas {
+			if a == candidate {
+				freeze(a)
+			} else {
+				freeze(b)
+			}
+		}
+		if values[a] == gas {
+			candidate = a
+		} else if values[b] == gas {
+			candidate = b
+		}
+		return values[a] < values[b]
+	}
+	return less, values
+}
+
+// TestQuicksortAdversary runs quicksort against McIlroy's adversary. Only
+// the heapsort fallback keeps this O(n log n): up to 2·log2(n) levels of
+// partitioning at 2 comparisons per item, then about 2·n·log2(n) for heapsort.
+func TestQuicksortAdversary(t *testing.T) {
+	for _, n := range []int{1000, 10000, 100000} {
+		less, values := adversary(n)
+		arr := datagen.Sorted(n)
+		s := &stats.Stats{}
+		sorter, _ := ByName[int]("quicksort")
+		sorter.SortStats(arr, less, s)
+
+		for i := 1; i < n; i++ {
+			if values[arr[i]] < values[arr[i-1]] {
+				t.Fatalf("n = %d: didn't sort the items", n)
+			}
+		}
+		if err := check_quicksort_stats(n, s, 8); err != nil {
+			t.Error(err)
+		}
+	}
+}
@@ -0,0 +1,118 @@
+package sorting
+
+import (
+	"math/bits"
+
+	"example.com/m/v2/stats"
+)
+
+// The radix sorts look at one byte of the key per pass.
+const radix_bits = 8
+const radix = 1 << radix_bits
+
+// RadixSort sorts arr in place with an LSD (least significant digit first) radix sort.
+func RadixSort[T Signed](arr []T) {
+	RadixSortFunc(arr, func(v T) int64 { return int64(v) })
+}
+
+// RadixSortStats is like RadixSort but records the work it does in s.
+func RadixSortStats[T Signed](arr []T, s *stats.Stats) {
+	radix_sort(arr, func(v T) int64 { return int64(v) }, s)
+}
+
+// RadixSortFunc sorts arr in place by key with an LSD radix sort.
+// It is stable: items with equal keys keep their original order.
+func RadixSortFunc[T any](arr []T, key func(T) int64) {
+	radix_sort(arr, key, nil)
+}
+
+func radix_sort[T any](arr []T, key func(T) int64, s *stats.Stats) {
+	if len(arr) <= 1 {
+		return
+	}
+
+	// Work with each key's offset from the smallest key, which is never
+	// negative and only needs as many passes as the spread of keys requires.
+	min_key, max_key := key(arr[0]), key(arr[0])
+	for _, v := range arr {
+		k := key(v)
+		if k < min_key {
+			min_key = k
+		}
+		if k > max_key {
+			max_key = k
+		}
+	}
+	spread := uint64(max_key) - uint64(min_key)
+	num_passes := (bits.Len64(spread) + radix_bits - 1) / radix_bits
+
+	src, dst := arr, make([]T, len(arr))
+	s.Alloc(len(arr))
+	for pass := 0; pass < num_passes; pass++ {
+		shift := pass * radix_bits
+		stable_place(src, dst, radix, func(v T) int {
+			offset := uint64(key(v)) - uint64(min_key)
+			return int(offset>>shift) & (radix - 1)
+		})
+		s.Write(len(arr))
+		src, dst = dst, src
+	}
+
+	// After an odd number of passes the result is in the scratch slice.
+	if num_passes%2 == 1 {
+		copy(arr, src)
+		s.Write(len(arr))
+	}
+}
+
+// RadixSortStrings sorts arr in place with an MSD (most significant digit first) radix sort.
+func RadixSortStrings(arr []string) {
+	RadixSortStringsFunc(arr, func(s string) string { return s })
+}
+
+// RadixSortStringsStats is like RadixSortStrings but records the work it does in s.
+func RadixSortStringsStats(arr []string, s *stats.Stats) {
+	radix_sort_strings(arr, func(s string) string { return s }, s)
+}
+
+// RadixSortStringsFunc sorts arr in place by key with an MSD radix sort, comparing
+// keys byte by byte. It is stable: items with equal keys keep their original order.
+func RadixSortStringsFunc[T any](arr []T, key func(T) string) {
+	radix_sort_strings(arr, key, nil)
+}
+
+func radix_sort_strings[T any](arr []T, key func(T) string, s *stats.Stats) {
+	buf := make([]T, len(arr))
+	s.Alloc(len(arr))
+	msd_radix_sort(arr, buf, key, 0, s)
+}
+
+// msd_radix_sort sorts arr, whose keys all share their first depth bytes.
+// The first call has depth 0, which s records as a recursion depth of 1.
+func msd_radix_sort[T any](arr, buf []T, key func(T) string, depth int, s *stats.Stats) {
+	s.Call(depth + 1)
+	if len(arr) <= insertion_sort_cutoff {
+		less := stats.CountLess(s, func(a, b T) bool { return key(a)[depth:] < key(b)[depth:] })
+		insertion_sort(arr, less, s)
+		return
+	}
+
+	// Bucket 0 holds the keys that end here, so they come first.
+	starts := stable_place(arr, buf, radix+1, func(v T) int {
+		k := key(v)
+		if depth >= len(k) {
+			return 0
+		}
+		return int(k[depth]) + 1
+	})
+	copy(arr, buf)
+	s.Write(2 * len(arr))
+
+	// Sort each of the other buckets on the next byte.
+	for b := 1; b <= radix; b++ {
+		lo, hi := starts[b], starts[b+1]
+		if hi-lo > 1 {
+			msd_radix_sort(arr[lo:hi], buf[lo:hi], key, depth+1, s)
+		}
+	}
+}
@@ -0,0 +1,188 @@
+package sorting
+
+import (
+	"fmt"
+	"math"
+
+	"example.com/m/v2/stats"
+)
+
+// Select rearranges arr so that arr[k] holds the item that would be there if
+// arr were sorted, with no greater items before it and no smaller items
+// after it, and returns that item. It panics if k is not an index of arr.
+//
+// It is a quickselect that partitions arr the same way as Quicksort but only
+// keeps going on the side that holds index k, which takes O(n) time on
+// average. If the pivots keep turning out badly it switches to the median of
+// medians, so the worst case is O(n) too.
+func Select[T Ordered](arr []T, k int) T {
+	return SelectFunc(arr, k, Less[T])
+}
+
+// SelectFunc is like Select but orders the items using less.
+func SelectFunc[T any](arr []T, k int, less func(a, b T) bool) T {
+	if k < 0 || k >= len(arr) {
+		panic(fmt.Sprintf("sorting: Select of index %d in %d items", k, len(arr)))
+	}
+	introselect(arr, k, less, 2*log2(len(arr)), nil)
+	return arr[k]
+}
+
+// Median rearranges arr like Select and returns its median. For an even
+// number of items it returns the lower of the two middle items. It panics if
+// arr is empty.
+func Median[T Ordered](arr []T) T {
+	return MedianFunc(arr, Less[T])
+}
+
+// MedianFunc is like Median but orders the items using less.
+func MedianFunc[T any](arr []T, less func(a, b T) bool) T {
+	return SelectFunc(arr, (len(arr)-1)/2, less)
+}
+
+// Percentile rearranges arr like Select and returns its p-th percentile
+// using the nearest-rank method: the smallest item that is greater than or
+// equal to p percent of the items. It panics if arr is empty or p is not in
+// the range [0, 100].
+func Percentile[T Ordered](arr []T, p float64) T {
+	return PercentileFunc(arr, p, Less[T])
+}
+
+// PercentileFunc is like Percentile but orders the items using less.
+func PercentileFunc[T any](arr []T, p float64, less func(a, b T) bool) T {
+	return SelectFunc(arr, PercentileRank(p, len(arr)), less)
+}
+
+// PercentileRank returns the index of the p-th percentile of n sorted items,
+// as used by Percentile. It panics if p is not in the range [0, 100].
+func PercentileRank(p float64, n int) int {
+	if !(p >= 0 && p <= 100) {
+		panic(fmt.Sprintf("sorting: percentile %v is not in [0, 100]", p))
+	}
+	rank := int(math.Ceil(p/100*float64(n))) - 1
+	if rank < 0 {
+		rank = 0
+	}
+	return rank
+}
+
+// MultiSelect rearranges arr so that every index in ranks holds the item
+// that would be there if arr were sorted, and returns those items in the
+// same order as ranks. Selecting m ranks together takes O(n log m) time,
+// less than selecting them one at a time. It panics if any rank is not an
+// index of arr.
+func MultiSelect[T Ordered](arr []T, ranks []int) []T {
+	return MultiSelectFunc(arr, ranks, Less[T])
+}
+
+// MultiSelectFunc is like MultiSelect but orders the items using less.
+func MultiSelectFunc[T any](arr []T, ranks []int, less func(a, b T) bool) []T {
+	for _, k := range ranks {
+		if k < 0 || k >= len(arr) {
+			panic(fmt.Sprintf("sorting: MultiSelect of index %d in %d items", k, len(arr)))
+		}
+	}
+
+	// Work on the distinct ranks in ascending order.
+	sorted_ranks := append([]int(nil), ranks...)
+	insertion_sort(sorted_ranks, Less[int], nil)
+	distinct := sorted_ranks[:0]
+	for i, k := range sorted_ranks {
+		if i == 0 || k != sorted_ranks[i-1] {
+			distinct = append(distinct, k)
+		}
+	}
+	multi_select(arr, distinct, less, nil)
+
+	result := make([]T, len(ranks))
+	for i, k := range ranks {
+		result[i] = arr[k]
+	}
+	return result
+}
+
+// multi_select selects each of the ascending, distinct ranks in arr.
+func multi_select[T any](arr []T, ranks []int, less func(a, b T) bool, s *stats.Stats) {
+	if len(ranks) == 0 {
+		return
+	}
+	if len(arr) <= insertion_sort_cutoff {
+		insertion_sort(arr, less, s)
+		return
+	}
+
+	// Select the middle rank. That splits arr into two parts, each holding
+	// about half of the remaining ranks.
+	mid := len(ranks) / 2
+	k := ranks[mid]
+	introselect(arr, k, less, 2*log2(len(arr)), s)
+
+	multi_select(arr[:k], ranks[:mid], less, s)
+
+	right := make([]int, len(ranks)-mid-1)
+	for i, r := range ranks[mid+1:] {
+		right[i] = r - (k + 1)
+	}
+	multi_select(arr[k+1:], right, less, s)
+}
+
+// introselect moves the k-th smallest item of arr to arr[k]. It tries up to
+// quick_tries pivots chosen like quicksort's before falling back on the
+// median of medians.
+func introselect[T any](arr []T, k int, less func(a, b T) bool, quick_tries int, s *stats.Stats) {
+	// The window arr[lo:hi] always contains index k.
+	lo, hi := 0, len(arr)
+	for hi-lo > insertion_sort_cutoff {
+		window := arr[lo:hi]
+
+		var p int
+		if quick_tries > 0 {
+			quick_tries--
+			p = choose_pivot(window, lo, less, s)
+		} else {
+			p = median_of_medians(window, less, s)
+		}
+
+		// Keep only the part that holds index k.
+		lt, gt := partition3_around(window, p, lo, less, s)
+		switch {
+		case k-lo < lt:
+			hi = lo + lt
+		case k-lo >= gt:
+			lo = lo + gt
+		default:
+			// arr[k] equals the pivot, which is in its final place.
+			return
+		}
+	}
+
+	sort_part(insertion_sort[T], arr[lo:hi], lo, less, s)
+}
+
+// median_of_medians returns the index of a pivot with at least about 30% of the items of
+// arr on either side of it. It splits arr into groups of five, moves the
+// median of each group to the front of arr and then finds the median of
+// those medians recursively.
+func median_of_medians[T any](arr []T, less func(a, b T) bool, s *stats.Stats) int {
+	num_groups := 0
+	for i := 0; i < len(arr); i += 5 {
+		end := i + 5
+		if end > len(arr) {
+			end = len(arr)
+		}
+		group := arr[i:end]
+		insertion_sort(group, less, s)
+
+		// Move the group's median into the front of arr.
+		m := i + len(group)/2
+		arr[num_groups], arr[m] = arr[m], arr[num_groups]
+		s.Swap()
+		num_groups++
+	}
+
+	// Find the median of the medians without any quickselect pivots, so the
+	// whole search stays linear in the worst case.
+	medians := arr[:num_groups]
+	introselect(medians, num_groups/2, less, 0, s)
+	return num_groups / 2
+}
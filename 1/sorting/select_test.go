@@ -0,0 +1,109 @@
+package sorting
+
+import (
+	"fmt"
+	"sort"
+	"testing"
+
+	"example.com/m/v2/datagen"
+	"example.com/m/v2/internal/testutil"
+	"example.com/m/v2/stats"
+)
+
+// TestSelect compares Select, Median and Percentile with a full sort and
+// checks that Select leaves the array partitioned around the chosen item.
+func TestSelect(t *testing.T) {
+	testutil.RunRounds(t, func(r *datagen.Generator, max_len int) error {
+		arr := r.Mixed(max_len)
+		if len(arr) == 0 {
+			return nil
+		}
+		sorted := append([]int(nil), arr...)
+		sort.Ints(sorted)
+
+		k := r.Intn(len(arr))
+		work := append([]int(nil), arr...)
+		if got := Select(work, k); got != sorted[k] {
+			return fmt.Errorf("Select(%s, %d) = %d, want %d", testutil.Describe(arr), k, got, sorted[k])
+		}
+		for i, v := range work {
+			if (i < k && v > work[k]) || (i > k && v < work[k]) {
+				return fmt.Errorf("Select(%s, %d) left %d at index %d around %d", testutil.Describe(arr), k, v, i, work[k])
+			}
+		}
+
+		median := (len(arr) - 1) / 2
+		if got := Median(append([]int(nil), arr...)); got != sorted[median] {
+			return fmt.Errorf("Median(%s) = %d, want %d", testutil.Describe(arr), got, sorted[median])
+		}
+
+		p := float64(r.Intn(101))
+		rank := 0
+		for rank+1 < len(sorted) && float64(rank+1) < p/100*float64(len(sorted)) {
+			rank++
+		}
+		if got := Percentile(append([]int(nil), arr...), p); got != sorted[rank] {
+			return fmt.Errorf("Percentile(%s, %v) = %d, want %d", testutil.Describe(arr), p, got, sorted[rank])
+		}
+		return nil
+	})
+}
+
+// TestMultiSelect compares MultiSelect with a full sort, using random ranks
+// that may repeat.
+func TestMultiSelect(t *testing.T) {
+	testutil.RunRounds(t, func(r *datagen.Generator, max_len int) error {
+		arr := r.Mixed(max_len)
+		if len(arr) == 0 {
+			return nil
+		}
+		sorted := append([]int(nil), arr...)
+		sort.Ints(sorted)
+
+		ranks := make([]int, r.Intn(8))
+		for i := range ranks {
+			ranks[i] = r.Intn(len(arr))
+		}
+
+		work := append([]int(nil), arr...)
+		got := MultiSelect(work, ranks)
+		for i, k := range ranks {
+			if got[i] != sorted[k] || work[k] != sorted[k] {
+				return fmt.Errorf("MultiSelect(%s, %v) = %v, want %d at rank %d", testutil.Describe(arr), ranks, got, sorted[k], k)
+			}
+		}
+		return nil
+	})
+}
+
+// TestSelectMedianOfMedians gives introselect no quickselect tries, so every
+// pivot comes from the median of medians, and checks that it selects the
+// right item in linear time.
+func TestSelectMedianOfMedians(t *testing.T) {
+	testutil.RunRounds(t, func(r *datagen.Generator, max_len int) error {
+		arr := r.Mixed(max_len * 10)
+		if len(arr) == 0 {
+			return nil
+		}
+		sorted := append([]int(nil), arr...)
+		sort.Ints(sorted)
+
+		k := r.Intn(len(arr))
+		work := append([]int(nil), arr...)
+		s := &stats.Stats{}
+		introselect(work, k, stats.CountLess(s, Less[int]), 0, s)
+		if work[k] != sorted[k] {
+			return fmt.Errorf("introselect(%s, %d) with no quick tries found %d, want %d", testutil.Describe(arr), k, work[k], sorted[k])
+		}
+		for i, v := range work {
+			if (i < k && v > work[k]) || (i > k && v < work[k]) {
+				return fmt.Errorf("introselect(%s, %d) left %d at index %d around %d", testutil.Describe(arr), k, v, i, work[k])
+			}
+		}
+		// It takes about 10 comparisons per item.
+		if max := int64(20 * len(arr)); s.Comparisons > max {
+			return fmt.Errorf("introselect of %d items with no quick tries made %d comparisons, want at most %d", len(arr), s.Comparisons, max)
+		}
+		return nil
+	})
+}
@@ -0,0 +1,101 @@
+package sorting
+
+import (
+	"math"
+
+	"example.com/m/v2/stats"
+)
+
+// GapSequence returns the gaps Shell sort uses for n items, largest first
+// and ending with 1.
+type GapSequence func(n int) []int
+
+// The gaps Marcin Ciura found experimentally to need the fewest comparisons.
+var ciura_gaps = []int{1, 4, 10, 23, 57, 132, 301, 701, 1750}
+
+// CiuraGaps is Ciura's sequence 1, 4, 10, 23, 57, 132, 301, 701, 1750,
+// extended past 1750 by multiplying by 2.25 each time.
+func CiuraGaps(n int) []int {
+	gaps := append([]int(nil), ciura_gaps...)
+	for gap := gaps[len(gaps)-1]; gap < n; {
+		gap = int(float64(gap) * 2.25)
+		gaps = append(gaps, gap)
+	}
+	return descending_gaps(gaps, n)
+}
+
+// SedgewickGaps is Sedgewick's 1986 sequence 1, 8, 23, 77, 281, ..., that is
+// 1 followed by 4^k + 3·2^(k-1) + 1. Shell sort takes O(n^(4/3)) time with it.
+func SedgewickGaps(n int) []int {
+	gaps := []int{1}
+	for k := 1; ; k++ {
+		gap := 1<<(2*k) + 3<<(k-1) + 1
+		if gap >= n {
+			break
+		}
+		gaps = append(gaps, gap)
+	}
+	return descending_gaps(gaps, n)
+}
+
+// TokudaGaps is Tokuda's sequence 1, 4, 9, 20, 46, 103, ..., the ceilings of
+// (9^k - 4^k) / (5·4^(k-1)).
+func TokudaGaps(n int) []int {
+	var gaps []int
+	for k := 1; ; k++ {
+		gap := int(math.Ceil(0.8 * (math.Pow(2.25, float64(k)) - 1)))
+		if gap >= n && k > 1 {
+			break
+		}
+		gaps = append(gaps, gap)
+	}
+	return descending_gaps(gaps, n)
+}
+
+// descending_gaps returns the ascending gaps that are less than n, largest
+// first. It always keeps the gap of 1.
+func descending_gaps(gaps []int, n int) []int {
+	var result []int
+	for i := len(gaps) - 1; i >= 0; i-- {
+		if gaps[i] < n || gaps[i] == 1 {
+			result = append(result, gaps[i])
+		}
+	}
+	return result
+}
+
+// ShellSort sorts arr in place with Shell sort using Ciura's gaps. Shell sort
+// runs an insertion sort over the items gap apart for each gap in turn, so
+// items move long distances early and the final pass, with a gap of 1, has
+// little left to do.
+func ShellSort[T Ordered](arr []T) {
+	ShellSortGapsFunc(arr, CiuraGaps, Less[T])
+}
+
+// ShellSortFunc sorts arr in place using less to order the items.
+func ShellSortFunc[T any](arr []T, less func(a, b T) bool) {
+	ShellSortGapsFunc(arr, CiuraGaps, less)
+}
+
+// ShellSortGaps sorts arr in place with Shell sort using the given gaps.
+func ShellSortGaps[T Ordered](arr []T, gaps GapSequence) {
+	ShellSortGapsFunc(arr, gaps, Less[T])
+}
+
+// ShellSortGapsFunc sorts arr in place with Shell sort using the given gaps
+// and using less to order the items.
+func ShellSortGapsFunc[T any](arr []T, gaps GapSequence, less func(a, b T) bool) {
+	shell_sort(arr, gaps, less, nil)
+}
+
+func shell_sort[T any](arr []T, gaps GapSequence, less func(a, b T) bool, s *stats.Stats) {
+	for _, gap := range gaps(len(arr)) {
+		// Insertion sort each of the gap interleaved slices at once.
+		for i := gap; i < len(arr); i++ {
+			for j := i; j >= gap && less(arr[j], arr[j-gap]); j -= gap {
+				arr[j], arr[j-gap] = arr[j-gap], arr[j]
+				s.Swap()
+			}
+		}
+	}
+}
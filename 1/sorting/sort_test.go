@@ -0,0 +1,364 @@
+package sorting
+
+import (
+	"fmt"
+	"sort"
+	"testing"
+
+	"example.com/m/v2/datagen"
+	"example.com/m/v2/internal/testutil"
+	"example.com/m/v2/stats"
+)
+
+// check_int_sort compares sort_ints with sort.Ints on a copy of arr.
+func check_int_sort(arr []int, sort_ints func([]int)) error {
+	want := append([]int(nil), arr...)
+	sort.Ints(want)
+
+	got := append([]int(nil), arr...)
+	sort_ints(got)
+	if !testutil.Equal(got, want) {
+		return fmt.Errorf("sorting %s gave %s", testutil.Describe(arr), testutil.Describe(got))
+	}
+	return nil
+}
+
+// benchmark_int_sort times sort_ints on random ints at each size, as
+// sub-benchmarks named n=size. Every run sorts a fresh copy of the same input.
+func benchmark_int_sort(b *testing.B, sizes []int, sort_ints func([]int)) {
+	for _, n := range sizes {
+		input := datagen.New(1).Uniform(n, n)
+		b.Run(fmt.Sprintf("n=%d", n), func(b *testing.B) {
+			arr := make([]int, n)
+			b.SetBytes(int64(8 * n))
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				b.StopTimer()
+				copy(arr, input)
+				b.StartTimer()
+				sort_ints(arr)
+			}
+		})
+	}
+}
+
+// customer is a datagen.Customer that remembers where it started, so the
+// tests can check that a sort kept ties in order.
+type customer struct {
+	datagen.Customer
+	position int
+}
+
+func by_purchases(a, b customer) bool {
+	return a.NumPurchases < b.NumPurchases
+}
+
+// random_customers returns customers with few distinct purchase counts, so
+// there are plenty of ties to test stability on, and the largest count plus one.
+func random_customers(r *datagen.Generator, max_len int) ([]customer, int) {
+	max := r.Intn(10) + 1
+	arr := make([]customer, r.Intn(max_len+1))
+	for i, c := range r.Customers(len(arr), max) {
+		arr[i] = customer{Customer: c, position: i}
+	}
+	return arr, max
+}
+
+// check_stable checks that sorted holds the n customers ordered by purchases,
+// with ties in their original order.
+func check_stable(sorted []customer, n int) error {
+	if len(sorted) != n {
+		return fmt.Errorf("sorting %d customers returned %d", n, len(sorted))
+	}
+	for i := 1; i < len(sorted); i++ {
+		a, b := sorted[i-1], sorted[i]
+		if a.NumPurchases > b.NumPurchases {
+			return fmt.Errorf("%v comes before %v", a, b)
+		}
+		if a.NumPurchases == b.NumPurchases && a.position > b.position {
+			return fmt.Errorf("%v and %v swapped places", a, b)
+		}
+	}
+	return nil
+}
+
+// check_stable_sort sorts a copy of arr with sort_customers and checks that
+// the result is ordered and stable.
+func check_stable_sort(arr []customer, sort_customers func([]customer)) error {
+	sorted := append([]customer(nil), arr...)
+	sort_customers(sorted)
+	return check_stable(sorted, len(arr))
+}
+
+func TestSortersMatchSortInts(t *testing.T) {
+	for _, name := range Names() {
+		sorter, _ := ByName[int](name)
+		t.Run(name, func(t *testing.T) {
+			testutil.RunRounds(t, func(r *datagen.Generator, max_len int) error {
+				return check_int_sort(r.Mixed(max_len), func(arr []int) { sorter.Sort(arr, Less[int]) })
+			})
+		})
+	}
+}
+
+func TestCountingSort(t *testing.T) {
+	testutil.RunRounds(t, func(r *datagen.Generator, max_len int) error {
+		arr := r.Mixed(max_len)
+		// Don't allocate counts for huge ranges.
+		if len(arr) == 0 || max_int(arr)-min_int(arr) > 1<<20 {
+			return nil
+		}
+		return check_int_sort(arr, func(arr []int) {
+			min, max := min_int(arr), max_int(arr)
+			copy(arr, CountingSort(arr, min, max+1, func(v int) int { return v }))
+		})
+	})
+}
+
+func TestCountingSortIsStable(t *testing.T) {
+	testutil.RunRounds(t, func(r *datagen.Generator, max_len int) error {
+		arr, max := random_customers(r, max_len)
+		return check_stable(CountingSort(arr, 0, max, func(c customer) int { return c.NumPurchases }), len(arr))
+	})
+}
+
+func TestRadixSort(t *testing.T) {
+	testutil.RunRounds(t, func(r *datagen.Generator, max_len int) error {
+		return check_int_sort(r.Mixed(max_len), RadixSort[int])
+	})
+}
+
+func TestRadixSortStrings(t *testing.T) {
+	testutil.RunRounds(t, func(r *datagen.Generator, max_len int) error {
+		// Short strings over a small alphabet share lots of prefixes.
+		arr := r.Strings(r.Intn(max_len+1), 0, 5, "ab\x00\xff")
+		want := append([]string(nil), arr...)
+		sort.Strings(want)
+
+		got := append([]string(nil), arr...)
+		RadixSortStrings(got)
+		for i := range got {
+			if got[i] != want[i] {
+				return fmt.Errorf("sorting %q gave %q", arr, got)
+			}
+		}
+		return nil
+	})
+}
+
+// TestDistributionSortStats checks the work counting sort and the radix
+// sorts record: one scratch item per item and whole passes of writes.
+func TestDistributionSortStats(t *testing.T) {
+	testutil.RunRounds(t, func(r *datagen.Generator, max_len int) error {
+		arr, max := random_customers(r, max_len)
+		n := int64(len(arr))
+
+		var s stats.Stats
+		sorted := CountingSortStats(arr, 0, max, func(c customer) int { return c.NumPurchases }, &s)
+		if err := check_stable(sorted, len(arr)); err != nil {
+			return err
+		}
+		if s.Allocations != n || s.Writes != n {
+			return fmt.Errorf("counting sort of %d items recorded %+v", n, s)
+		}
+
+		ints := r.Mixed(max_len)
+		s.Reset()
+		if err := check_int_sort(ints, func(arr []int) { RadixSortStats(arr, &s) }); err != nil {
+			return err
+		}
+		n = int64(len(ints))
+		if n > 1 && (s.Allocations != n || s.Writes%n != 0 || s.Writes > 9*n) {
+			return fmt.Errorf("radix sort of %d items recorded %+v", n, s)
+		}
+
+		strs := r.Strings(r.Intn(max_len+1), 0, 5, "ab\x00\xff")
+		s.Reset()
+		RadixSortStringsStats(strs, &s)
+		if !sort.StringsAreSorted(strs) || s.Allocations != int64(len(strs)) || s.Calls < 1 {
+			return fmt.Errorf("string radix sort of %d items recorded %+v", len(strs), s)
+		}
+		return nil
+	})
+}
+
+func TestMergeSortsAreStable(t *testing.T) {
+	testutil.RunRounds(t, func(r *datagen.Generator, max_len int) error {
+		arr, _ := random_customers(r, max_len)
+		for _, sort_customers := range []func([]customer){
+			func(arr []customer) { MergeSortFunc(arr, by_purchases) },
+			func(arr []customer) { ParallelMergeSortFunc(arr, by_purchases, 4) },
+			func(arr []customer) { MergeSortBottomUpFunc(arr, by_purchases) },
+			func(arr []customer) { TimsortFunc(arr, by_purchases) },
+		} {
+			if err := check_stable_sort(arr, sort_customers); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// TestParallelSortsLarge sorts slices long enough for the parallel sorts to
+// hand work to other goroutines, which the short inputs of the other tests
+// never are.
+func TestParallelSortsLarge(t *testing.T) {
+	for seed := int64(0); seed < 5; seed++ {
+		r := datagen.New(seed)
+		n := parallel_cutoff*4 + r.Intn(parallel_cutoff)
+		arr := r.Uniform(n, n/2)
+		for _, p := range []int{2, 3, 8} {
+			if err := check_int_sort(arr, func(arr []int) { ParallelQuicksort(arr, p) }); err != nil {
+				t.Fatalf("seed %d: ParallelQuicksort with parallelism %d: %v", seed, p, err)
+			}
+			if err := check_int_sort(arr, func(arr []int) { ParallelMergeSort(arr, p) }); err != nil {
+				t.Fatalf("seed %d: ParallelMergeSort with parallelism %d: %v", seed, p, err)
+			}
+		}
+
+		customers := make([]customer, n)
+		for i, c := range r.Customers(n, 10) {
+			customers[i] = customer{Customer: c, position: i}
+		}
+		err := check_stable_sort(customers, func(arr []customer) { ParallelMergeSortFunc(arr, by_purchases, 4) })
+		if err != nil {
+			t.Fatalf("seed %d: ParallelMergeSortFunc: %v", seed, err)
+		}
+	}
+}
+
+// TestParallelOddEvenSortLarge sorts slices long enough to be split into
+// chunks, including pairs that straddle two chunks in the odd phase.
+func TestParallelOddEvenSortLarge(t *testing.T) {
+	for seed := int64(0); seed < 3; seed++ {
+		r := datagen.New(seed)
+		n := odd_even_parallel_cutoff + r.Intn(200)
+		for _, p := range []int{2, 3, 7} {
+			err := check_int_sort(r.Uniform(n, n), func(arr []int) { ParallelOddEvenSort(arr, p) })
+			if err != nil {
+				t.Fatalf("seed %d: parallelism %d: %v", seed, p, err)
+			}
+
+			customers := make([]customer, n)
+			for i, c := range r.Customers(n, 10) {
+				customers[i] = customer{Customer: c, position: i}
+			}
+			err = check_stable_sort(customers, func(arr []customer) { ParallelOddEvenSortFunc(arr, by_purchases, p) })
+			if err != nil {
+				t.Fatalf("seed %d: parallelism %d: %v", seed, p, err)
+			}
+		}
+	}
+}
+
+// TestExchangeSortsAreStable checks the stable sorts in the bubble sort family.
+func TestExchangeSortsAreStable(t *testing.T) {
+	testutil.RunRounds(t, func(r *datagen.Generator, max_len int) error {
+		arr, _ := random_customers(r, max_len)
+		for _, sort_customers := range []func([]customer){
+			func(arr []customer) { BubbleSortFunc(arr, by_purchases) },
+			func(arr []customer) { CocktailShakerSortFunc(arr, by_purchases) },
+			func(arr []customer) { GnomeSortFunc(arr, by_purchases) },
+			func(arr []customer) { InsertionSortFunc(arr, by_purchases) },
+			func(arr []customer) { BinaryInsertionSortFunc(arr, by_purchases) },
+			func(arr []customer) { OddEvenSortFunc(arr, by_purchases) },
+			func(arr []customer) { ParallelOddEvenSortFunc(arr, by_purchases, 4) },
+		} {
+			if err := check_stable_sort(arr, sort_customers); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+func min_int(arr []int) int {
+	min := arr[0]
+	for _, v := range arr {
+		if v < min {
+			min = v
+		}
+	}
+	return min
+}
+
+func max_int(arr []int) int {
+	max := arr[0]
+	for _, v := range arr {
+		if v > max {
+			max = v
+		}
+	}
+	return max
+}
+
+// fuzz_ints turns the fuzzer's bytes into ints, one per byte, that are small
+// enough to repeat often and are sometimes negative.
+func fuzz_ints(data []byte) []int {
+	arr := make([]int, len(data))
+	for i, b := range data {
+		arr[i] = int(int8(b))
+	}
+	return arr
+}
+
+// fuzz_customers turns the fuzzer's bytes into customers with fewer than 8
+// purchases each, so there are plenty of ties.
+func fuzz_customers(data []byte) []customer {
+	arr := make([]customer, len(data))
+	for i, b := range data {
+		arr[i] = customer{Customer: datagen.Customer{ID: fmt.Sprintf("C%d", i), NumPurchases: int(b % 8)}, position: i}
+	}
+	return arr
+}
+
+// add_fuzz_seeds gives a fuzz target a few inputs to start from.
+func add_fuzz_seeds(f *testing.F) {
+	f.Add([]byte{})
+	f.Add([]byte{1})
+	f.Add([]byte{3, 1, 2})
+	f.Add([]byte{255, 0, 128, 127, 0, 255})
+	f.Add([]byte("a stable sort keeps equal keys in order"))
+}
+
+func FuzzQuicksort(f *testing.F) {
+	add_fuzz_seeds(f)
+	f.Fuzz(func(t *testing.T, data []byte) {
+		arr := fuzz_ints(data)
+		if err := check_int_sort(arr, Quicksort[int]); err != nil {
+			t.Fatal(err)
+		}
+		if err := check_int_sort(arr, func(arr []int) { QuicksortFunc(arr, Less[int]) }); err != nil {
+			t.Fatal(err)
+		}
+	})
+}
+
+func FuzzBubbleSort(f *testing.F) {
+	add_fuzz_seeds(f)
+	f.Fuzz(func(t *testing.T, data []byte) {
+		if err := check_int_sort(fuzz_ints(data), BubbleSort[int]); err != nil {
+			t.Fatal(err)
+		}
+		err := check_stable_sort(fuzz_customers(data), func(arr []customer) { BubbleSortFunc(arr, by_purchases) })
+		if err != nil {
+			t.Fatal(err)
+		}
+	})
+}
+
+func FuzzCountingSort(f *testing.F) {
+	add_fuzz_seeds(f)
+	f.Fuzz(func(t *testing.T, data []byte) {
+		err := check_int_sort(fuzz_ints(data), func(arr []int) {
+			copy(arr, CountingSort(arr, -128, 128, func(v int) int { return v }))
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		customers := fuzz_customers(data)
+		if err := check_stable(CountingSort(customers, 0, 8, func(c customer) int { return c.NumPurchases }), len(customers)); err != nil {
+			t.Fatal(err)
+		}
+	})
+}
@@ -0,0 +1,99 @@
+package sorting
+
+import (
+	"fmt"
+
+	"example.com/m/v2/stats"
+)
+
+// Sorter is a comparison sort that can be chosen by name at run time.
+type Sorter[T any] interface {
+	// Name is the name the algorithm is registered under.
+	Name() string
+	// Sort sorts arr in place using less to order the items.
+	Sort(arr []T, less func(a, b T) bool)
+	// SortStats is like Sort but records the work it does in s.
+	SortStats(arr []T, less func(a, b T) bool, s *stats.Stats)
+}
+
+type sort_func[T any] func(arr []T, less func(a, b T) bool, s *stats.Stats)
+
+type named_sorter[T any] struct {
+	name string
+	sort sort_func[T]
+}
+
+func (ns named_sorter[T]) Name() string {
+	return ns.name
+}
+
+func (ns named_sorter[T]) Sort(arr []T, less func(a, b T) bool) {
+	ns.sort(arr, less, nil)
+}
+
+func (ns named_sorter[T]) SortStats(arr []T, less func(a, b T) bool, s *stats.Stats) {
+	ns.sort(arr, stats.CountLess(s, less), s)
+}
+
+// sorters lists every registered comparison sort.
+func sorters[T any]() []Sorter[T] {
+	return []Sorter[T]{
+		named_sorter[T]{"adaptive_sort", func(arr []T, less func(a, b T) bool, s *stats.Stats) {
+			adaptive_sort(arr, less, s)
+		}},
+		named_sorter[T]{"binary_insertion_sort", func(arr []T, less func(a, b T) bool, s *stats.Stats) {
+			binary_insertion_sort(arr, 1, less, s)
+		}},
+		named_sorter[T]{"block_quicksort", block_quicksort[T]},
+		named_sorter[T]{"bubble_sort", bubble_sort[T]},
+		named_sorter[T]{"cocktail_shaker_sort", cocktail_shaker_sort[T]},
+		named_sorter[T]{"comb_sort", comb_sort[T]},
+		named_sorter[T]{"gnome_sort", gnome_sort[T]},
+		named_sorter[T]{"heap_sort", heap_sort[T]},
+		named_sorter[T]{"insertion_sort", insertion_sort[T]},
+		named_sorter[T]{"merge_sort", merge_sort_top_down[T]},
+		named_sorter[T]{"merge_sort_bottom_up", merge_sort_bottom_up[T]},
+		named_sorter[T]{"odd_even_sort", odd_even_sort[T]},
+		named_sorter[T]{"parallel_merge_sort", func(arr []T, less func(a, b T) bool, s *stats.Stats) {
+			parallel_merge_sort(arr, less, 0, s)
+		}},
+		named_sorter[T]{"parallel_odd_even_sort", func(arr []T, less func(a, b T) bool, s *stats.Stats) {
+			parallel_odd_even_sort(arr, less, 0, s)
+		}},
+		named_sorter[T]{"parallel_quicksort", func(arr []T, less func(a, b T) bool, s *stats.Stats) {
+			parallel_quicksort(arr, less, 0, s)
+		}},
+		named_sorter[T]{"quicksort", quicksort[T]},
+		named_sorter[T]{"quicksort_lomuto", quicksort_lomuto_top[T]},
+		named_sorter[T]{"shell_sort_ciura", shell_sort_with[T](CiuraGaps)},
+		named_sorter[T]{"shell_sort_sedgewick", shell_sort_with[T](SedgewickGaps)},
+		named_sorter[T]{"shell_sort_tokuda", shell_sort_with[T](TokudaGaps)},
+		named_sorter[T]{"timsort", timsort[T]},
+	}
+}
+
+// shell_sort_with returns a Shell sort that uses gaps.
+func shell_sort_with[T any](gaps GapSequence) sort_func[T] {
+	return func(arr []T, less func(a, b T) bool, s *stats.Stats) {
+		shell_sort(arr, gaps, less, s)
+	}
+}
+
+// Names returns the names of the registered comparison sorts.
+func Names() []string {
+	var names []string
+	for _, s := range sorters[int]() {
+		names = append(names, s.Name())
+	}
+	return names
+}
+
+// ByName returns the comparison sort registered under name.
+func ByName[T any](name string) (Sorter[T], error) {
+	for _, s := range sorters[T]() {
+		if s.Name() == name {
+			return s, nil
+		}
+	}
+	return nil, fmt.Errorf("sorting: unknown algorithm %q", name)
+}
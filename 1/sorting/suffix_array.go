@@ -0,0 +1,254 @@
+package sorting
+
+// SuffixArray returns the suffix array of text: the starting index of every
+// suffix of text, ordered so that the suffixes are in increasing byte order.
+// It uses SA-IS (Nong, Zhang and Chan's induced sorting) and runs in O(n)
+// time.
+func SuffixArray(text string) []int {
+	s := make([]int, len(text))
+	for i := 0; i < len(text); i++ {
+		s[i] = int(text[i])
+	}
+	return sa_is(s, 255)
+}
+
+// SuffixArrayDoubling returns the same suffix array as SuffixArray using
+// prefix doubling in O(n log n) time.
+//
+// After round k every suffix has a rank that orders it by its first 2^k
+// bytes. Ordering by the first 2^(k+1) bytes is then ordering by the pair
+// (rank of the suffix, rank of the suffix 2^k further on), which two counting
+// sort passes do, least significant key first, as in LSD radix sort.
+func SuffixArrayDoubling(text string) []int {
+	n := len(text)
+	sa := make([]int, n)
+	if n == 0 {
+		return sa
+	}
+
+	// Start by ordering the suffixes by their first byte.
+	order := make([]int, n)
+	for i := range order {
+		order[i] = i
+	}
+	stable_place(order, sa, 256, func(i int) int { return int(text[i]) })
+
+	rank := make([]int, n)
+	for i := 1; i < n; i++ {
+		rank[sa[i]] = rank[sa[i-1]]
+		if text[sa[i]] != text[sa[i-1]] {
+			rank[sa[i]]++
+		}
+	}
+
+	next_rank := make([]int, n)
+	for k := 1; rank[sa[n-1]] < n-1; k *= 2 {
+		num_ranks := rank[sa[n-1]] + 1
+
+		// Suffixes shorter than k sort before everything else on the second
+		// key, so they get bucket 0 and the rest are shifted up by one.
+		second := func(i int) int {
+			if i+k < n {
+				return rank[i+k] + 1
+			}
+			return 0
+		}
+		stable_place(sa, order, num_ranks+1, second)
+		stable_place(order, sa, num_ranks, func(i int) int { return rank[i] })
+
+		// Suffixes share a rank only if both keys are equal.
+		next_rank[sa[0]] = 0
+		for i := 1; i < n; i++ {
+			a, b := sa[i-1], sa[i]
+			next_rank[b] = next_rank[a]
+			if rank[a] != rank[b] || second(a) != second(b) {
+				next_rank[b]++
+			}
+		}
+		rank, next_rank = next_rank, rank
+	}
+	return sa
+}
+
+// sa_is returns the suffix array of s, whose values are in [0, upper].
+//
+// Each suffix is S-type if it is less than the suffix after it and L-type if
+// it is greater. An LMS (leftmost S) suffix is an S-type suffix just after an
+// L-type one. Once the LMS suffixes are in order, one pass from the left
+// puts the L-type suffixes in place and one from the right the S-type ones.
+// Sorting the LMS suffixes is a smaller suffix array problem, at most half
+// the size, on names given to the strings between consecutive LMS suffixes.
+func sa_is(s []int, upper int) []int {
+	n := len(s)
+	switch n {
+	case 0:
+		return []int{}
+	case 1:
+		return []int{0}
+	case 2:
+		if s[0] < s[1] {
+			return []int{0, 1}
+		}
+		return []int{1, 0}
+	}
+
+	// is_s[i] reports whether suffix i is S-type. The last suffix is L-type.
+	is_s := make([]bool, n)
+	for i := n - 2; i >= 0; i-- {
+		if s[i] == s[i+1] {
+			is_s[i] = is_s[i+1]
+		} else {
+			is_s[i] = s[i] < s[i+1]
+		}
+	}
+
+	// Within the bucket for value v the L-type suffixes come first, starting
+	// at sum_l[v], and the S-type ones start at sum_s[v].
+	sum_l := make([]int, upper+1)
+	sum_s := make([]int, upper+1)
+	for i := 0; i < n; i++ {
+		if !is_s[i] {
+			sum_s[s[i]]++
+		} else {
+			sum_l[s[i]+1]++
+		}
+	}
+	for v := 0; v <= upper; v++ {
+		sum_s[v] += sum_l[v]
+		if v < upper {
+			sum_l[v+1] += sum_s[v]
+		}
+	}
+
+	sa := make([]int, n)
+	buf := make([]int, upper+1)
+	induce := func(lms []int) {
+		for i := range sa {
+			sa[i] = -1
+		}
+
+		// Drop the LMS suffixes at the start of the S-type part of their buckets.
+		copy(buf, sum_s)
+		for _, d := range lms {
+			sa[buf[s[d]]] = d
+			buf[s[d]]++
+		}
+
+		// Left to right, each suffix places the L-type suffix before it.
+		copy(buf, sum_l)
+		sa[buf[s[n-1]]] = n - 1
+		buf[s[n-1]]++
+		for i := 0; i < n; i++ {
+			if v := sa[i]; v >= 1 && !is_s[v-1] {
+				sa[buf[s[v-1]]] = v - 1
+				buf[s[v-1]]++
+			}
+		}
+
+		// Right to left, each suffix places the S-type suffix before it at
+		// the end of its bucket.
+		copy(buf, sum_l)
+		for i := n - 1; i >= 0; i-- {
+			if v := sa[i]; v >= 1 && is_s[v-1] {
+				buf[s[v-1]+1]--
+				sa[buf[s[v-1]+1]] = v - 1
+			}
+		}
+	}
+
+	// Number the LMS suffixes from left to right.
+	lms_map := make([]int, n+1)
+	for i := range lms_map {
+		lms_map[i] = -1
+	}
+	var lms []int
+	for i := 1; i < n; i++ {
+		if !is_s[i-1] && is_s[i] {
+			lms_map[i] = len(lms)
+			lms = append(lms, i)
+		}
+	}
+	m := len(lms)
+
+	// Inducing from the LMS suffixes in any order sorts the LMS substrings.
+	induce(lms)
+	if m == 0 {
+		return sa
+	}
+
+	sorted_lms := make([]int, 0, m)
+	for _, v := range sa {
+		if lms_map[v] != -1 {
+			sorted_lms = append(sorted_lms, v)
+		}
+	}
+
+	// Name each LMS substring by its rank, giving equal substrings equal names.
+	reduced := make([]int, m)
+	name := 0
+	reduced[lms_map[sorted_lms[0]]] = 0
+	for i := 1; i < m; i++ {
+		l, r := sorted_lms[i-1], sorted_lms[i]
+		end_l, end_r := n, n
+		if lms_map[l]+1 < m {
+			end_l = lms[lms_map[l]+1]
+		}
+		if lms_map[r]+1 < m {
+			end_r = lms[lms_map[r]+1]
+		}
+
+		same := end_l-l == end_r-r
+		if same {
+			for l < end_l && s[l] == s[r] {
+				l++
+				r++
+			}
+			same = l < n && r < n && s[l] == s[r]
+		}
+		if !same {
+			name++
+		}
+		reduced[lms_map[sorted_lms[i]]] = name
+	}
+
+	// Sort the LMS suffixes properly by recursing on the names, then induce
+	// the full suffix array from them.
+	reduced_sa := sa_is(reduced, name)
+	for i, v := range reduced_sa {
+		sorted_lms[i] = lms[v]
+	}
+	induce(sorted_lms)
+	return sa
+}
+
+// LCPArray returns the longest common prefix array of text for its suffix
+// array sa: lcp[i] is the length of the longest common prefix of the suffixes
+// sa[i-1] and sa[i], and lcp[0] is 0. It uses Kasai's algorithm, which runs in
+// O(n) time.
+func LCPArray(text string, sa []int) []int {
+	n := len(text)
+	lcp := make([]int, n)
+	rank := make([]int, n)
+	for i, v := range sa {
+		rank[v] = i
+	}
+
+	// Going from suffix i to suffix i+1 drops one byte from the front, so
+	// the common prefix with its neighbour shrinks by at most one.
+	h := 0
+	for i := 0; i < n; i++ {
+		if rank[i] == 0 {
+			h = 0
+			continue
+		}
+		j := sa[rank[i]-1]
+		for i+h < n && j+h < n && text[i+h] == text[j+h] {
+			h++
+		}
+		lcp[rank[i]] = h
+		if h > 0 {
+			h--
+		}
+	}
+	return lcp
+}
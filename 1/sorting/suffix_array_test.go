@@ -0,0 +1,44 @@
+package sorting
+
+import (
+	"fmt"
+	"sort"
+	"testing"
+
+	"example.com/m/v2/datagen"
+	"example.com/m/v2/internal/testutil"
+)
+
+// TestSuffixArray compares both suffix array constructions and the LCP array
+// with sorting the suffixes directly.
+func TestSuffixArray(t *testing.T) {
+	testutil.RunRounds(t, func(r *datagen.Generator, max_len int) error {
+		text := testutil.RandomText(r, max_len)
+		want := make([]int, len(text))
+		for i := range want {
+			want[i] = i
+		}
+		sort.Slice(want, func(i, j int) bool { return text[want[i]:] < text[want[j]:] })
+
+		sa := SuffixArray(text)
+		doubling := SuffixArrayDoubling(text)
+		if !testutil.Equal(sa, want) || !testutil.Equal(doubling, want) {
+			return fmt.Errorf("suffix arrays of %q: SA-IS %v, prefix doubling %v, want %v", text, sa, doubling, want)
+		}
+
+		lcp := LCPArray(text, sa)
+		for i := range lcp {
+			common := 0
+			if i > 0 {
+				a, b := text[sa[i-1]:], text[sa[i]:]
+				for common < len(a) && common < len(b) && a[common] == b[common] {
+					common++
+				}
+			}
+			if lcp[i] != common {
+				return fmt.Errorf("LCPArray(%q)[%d] = %d, want %d", text, i, lcp[i], common)
+			}
+		}
+		return nil
+	})
+}
@@ -0,0 +1,126 @@
+package sorting
+
+import "example.com/m/v2/stats"
+
+// Timsort sorts arr in place. It is stable and uses O(n) extra memory.
+//
+// It is a natural merge sort in the style of Timsort: it finds the runs
+// that are already in order (reversing descending ones), extends short runs
+// with binary insertion sort and merges the runs while keeping their lengths
+// balanced. Input that is already mostly sorted takes close to linear time.
+func Timsort[T Ordered](arr []T) {
+	TimsortFunc(arr, Less[T])
+}
+
+// TimsortFunc is like Timsort but orders the items using less.
+func TimsortFunc[T any](arr []T, less func(a, b T) bool) {
+	timsort(arr, less, nil)
+}
+
+// sorted_run is a sorted stretch arr[start:start+length].
+type sorted_run struct {
+	start, length int
+}
+
+func timsort[T any](arr []T, less func(a, b T) bool, s *stats.Stats) {
+	n := len(arr)
+	if n < 2 {
+		return
+	}
+
+	min_run := min_run_length(n)
+	buf := make([]T, n)
+	s.Alloc(n)
+
+	var runs []sorted_run
+	for lo := 0; lo < n; {
+		// Find the next run and make it at least min_run long.
+		length := make_run_ascending(arr[lo:], less, s)
+		if length < min_run {
+			forced := min_run
+			if forced > n-lo {
+				forced = n - lo
+			}
+			binary_insertion_sort(arr[lo:lo+forced], length, less, s)
+			length = forced
+		}
+
+		runs = append(runs, sorted_run{lo, length})
+		runs = merge_collapse(arr, buf, runs, less, s)
+		lo += length
+	}
+
+	// Merge whatever runs remain, newest first.
+	for len(runs) > 1 {
+		runs = merge_at(arr, buf, runs, len(runs)-2, less, s)
+	}
+}
+
+// min_run_length returns the shortest run worth merging for n items. It is
+// between 32 and 64 and chosen so that n/min_run is close to a power of 2,
+// which keeps the final merges balanced.
+func min_run_length(n int) int {
+	r := 0
+	for n >= 64 {
+		r |= n & 1
+		n >>= 1
+	}
+	return n + r
+}
+
+// make_run_ascending returns the length of the run at the start of arr. If
+// the run is strictly descending it is reversed. Descending runs must be
+// strict, or reversing them would reorder equal items and break stability.
+func make_run_ascending[T any](arr []T, less func(a, b T) bool, s *stats.Stats) int {
+	if len(arr) < 2 {
+		return len(arr)
+	}
+
+	end := 2
+	if less(arr[1], arr[0]) {
+		for end < len(arr) && less(arr[end], arr[end-1]) {
+			end++
+		}
+		for i, j := 0, end-1; i < j; i, j = i+1, j-1 {
+			arr[i], arr[j] = arr[j], arr[i]
+			s.Swap()
+		}
+	} else {
+		for end < len(arr) && !less(arr[end], arr[end-1]) {
+			end++
+		}
+	}
+	return end
+}
+
+// merge_collapse merges runs on top of the stack until their lengths shrink
+// geometrically from bottom to top, so no merge is ever badly unbalanced.
+// For the top three runs X, Y, Z (Z newest) it requires |X| > |Y| + |Z| and
+// |Y| > |Z|, and also checks one run deeper to keep the rule from breaking
+// further down.
+func merge_collapse[T any](arr, buf []T, runs []sorted_run, less func(a, b T) bool, s *stats.Stats) []sorted_run {
+	for len(runs) > 1 {
+		n := len(runs) - 2
+		if (n > 0 && runs[n-1].length <= runs[n].length+runs[n+1].length) ||
+			(n > 1 && runs[n-2].length <= runs[n-1].length+runs[n].length) {
+			// Merge the middle run with whichever neighbour is shorter.
+			if runs[n-1].length < runs[n+1].length {
+				n--
+			}
+		} else if runs[n].length > runs[n+1].length {
+			break
+		}
+		runs = merge_at(arr, buf, runs, n, less, s)
+	}
+	return runs
+}
+
+// merge_at merges runs[i] with runs[i+1] and returns the shorter stack.
+func merge_at[T any](arr, buf []T, runs []sorted_run, i int, less func(a, b T) bool, s *stats.Stats) []sorted_run {
+	a, b := runs[i], runs[i+1]
+	lo, hi := a.start, b.start+b.length
+	merge_halves(arr[lo:hi], buf[lo:hi], a.length, less, s)
+
+	runs[i] = sorted_run{a.start, a.length + b.length}
+	return append(runs[:i+1], runs[i+2:]...)
+}
@@ -0,0 +1,79 @@
+package sorting
+
+import "example.com/m/v2/heap"
+
+// Smallest returns the k smallest items of arr in ascending order without
+// changing arr. It takes O(n log k) time and O(k) extra memory, so it is much
+// cheaper than sorting when only the first few items are wanted.
+func Smallest[T Ordered](arr []T, k int) []T {
+	return SmallestFunc(arr, k, Less[T])
+}
+
+// SmallestFunc is like Smallest but orders the items using less.
+func SmallestFunc[T any](arr []T, k int, less func(a, b T) bool) []T {
+	if k > len(arr) {
+		k = len(arr)
+	}
+	if k <= 0 {
+		return nil
+	}
+
+	// Keep the k smallest items seen so far in a max-heap, so the largest
+	// of them is on top, ready to be pushed out by a smaller item.
+	kept := make([]T, k)
+	copy(kept, arr[:k])
+	h := heap.Heapify(kept, func(a, b T) bool { return less(b, a) })
+	for _, v := range arr[k:] {
+		if less(v, h.Peek()) {
+			h.ReplaceTop(v)
+		}
+	}
+
+	// Popping fills kept from the back with the largest first.
+	for h.Len() > 1 {
+		h.Pop()
+	}
+	return kept
+}
+
+// Largest returns the k largest items of arr in descending order without
+// changing arr. It takes O(n log k) time and O(k) extra memory.
+func Largest[T Ordered](arr []T, k int) []T {
+	return LargestFunc(arr, k, Less[T])
+}
+
+// LargestFunc is like Largest but orders the items using less.
+func LargestFunc[T any](arr []T, k int, less func(a, b T) bool) []T {
+	return SmallestFunc(arr, k, func(a, b T) bool { return less(b, a) })
+}
+
+// PartialSort rearranges arr in place so that arr[:k] holds its k smallest
+// items in ascending order. The rest of arr is left in no particular order.
+// It takes O(n log k) time.
+func PartialSort[T Ordered](arr []T, k int) {
+	PartialSortFunc(arr, k, Less[T])
+}
+
+// PartialSortFunc is like PartialSort but orders the items using less.
+func PartialSortFunc[T any](arr []T, k int, less func(a, b T) bool) {
+	if k > len(arr) {
+		k = len(arr)
+	}
+	if k <= 0 {
+		return
+	}
+
+	// Build a max-heap of the first k items, then swap every smaller item
+	// from the rest of arr with the top of the heap.
+	h := heap.Heapify(arr[:k], func(a, b T) bool { return less(b, a) })
+	for i := k; i < len(arr); i++ {
+		if less(arr[i], h.Peek()) {
+			arr[i] = h.ReplaceTop(arr[i])
+		}
+	}
+
+	// Heapsort the first k items.
+	for h.Len() > 1 {
+		h.Pop()
+	}
+}
@@ -0,0 +1,49 @@
+package sorting
+
+import (
+	"fmt"
+	"sort"
+	"testing"
+
+	"example.com/m/v2/datagen"
+	"example.com/m/v2/internal/testutil"
+)
+
+// TestTopK compares Smallest, Largest and PartialSort with a full sort.
+func TestTopK(t *testing.T) {
+	testutil.RunRounds(t, func(r *datagen.Generator, max_len int) error {
+		arr := r.Mixed(max_len)
+		k := r.Intn(len(arr) + 2)
+
+		sorted := append([]int(nil), arr...)
+		sort.Ints(sorted)
+		want := sorted
+		if k < len(sorted) {
+			want = sorted[:k]
+		}
+
+		if got := Smallest(arr, k); !testutil.Equal(got, want) {
+			return fmt.Errorf("Smallest(%s, %d) = %v, want %v", testutil.Describe(arr), k, got, want)
+		}
+
+		reversed := make([]int, len(want))
+		for i := range want {
+			reversed[i] = sorted[len(sorted)-1-i]
+		}
+		if got := Largest(arr, k); !testutil.Equal(got, reversed) {
+			return fmt.Errorf("Largest(%s, %d) = %v, want %v", testutil.Describe(arr), k, got, reversed)
+		}
+
+		partial := append([]int(nil), arr...)
+		PartialSort(partial, k)
+		if !testutil.Equal(partial[:len(want)], want) {
+			return fmt.Errorf("PartialSort(%s, %d) gave %v", testutil.Describe(arr), k, partial)
+		}
+		rest := append([]int(nil), partial...)
+		sort.Ints(rest)
+		if !testutil.Equal(rest, sorted) {
+			return fmt.Errorf("PartialSort(%s, %d) lost items", testutil.Describe(arr), k)
+		}
+		return nil
+	})
+}
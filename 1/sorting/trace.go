@@ -0,0 +1,34 @@
+package sorting
+
+import "example.com/m/v2/stats"
+
+// shifted_tracer reports the steps of a sort of the part of a slice that
+// starts at offset as steps of the whole slice.
+type shifted_tracer struct {
+	stats.Tracer
+	offset int
+}
+
+func (t shifted_tracer) Compare(i, j int) { t.Tracer.Compare(t.offset+i, t.offset+j) }
+func (t shifted_tracer) Swap(i, j int)    { t.Tracer.Swap(t.offset+i, t.offset+j) }
+func (t shifted_tracer) Pivot(i int)      { t.Tracer.Pivot(t.offset + i) }
+func (t shifted_tracer) Range(lo, hi int) { t.Tracer.Range(t.offset+lo, t.offset+hi) }
+func (t shifted_tracer) Boundary(i int)   { t.Tracer.Boundary(t.offset + i) }
+
+// sort_part sorts arr with sort. arr starts at index offset of the slice
+// being sorted, which is where sort's steps are reported to s's Tracer.
+func sort_part[T any](sort sort_func[T], arr []T, offset int, less func(a, b T) bool, s *stats.Stats) {
+	// This is small enough to inline, so that sort is called directly when
+	// there is nothing to shift.
+	if offset == 0 || s.GetTracer() == nil {
+		sort(arr, less, s)
+		return
+	}
+	sort_shifted(sort, arr, offset, less, s)
+}
+
+func sort_shifted[T any](sort sort_func[T], arr []T, offset int, less func(a, b T) bool, s *stats.Stats) {
+	part := &stats.Stats{Tracer: shifted_tracer{s.Tracer, offset}}
+	sort(arr, less, part)
+	s.Add(part)
+}
@@ -0,0 +1,80 @@
+package sorting
+
+import (
+	"fmt"
+	"testing"
+
+	"example.com/m/v2/datagen"
+	"example.com/m/v2/internal/testutil"
+	"example.com/m/v2/stats"
+)
+
+// replayer is a stats.Tracer that repeats the reported swaps on its own copy
+// of the input and checks that every index is in range.
+type replayer struct {
+	arr []int
+	err error
+}
+
+func (r *replayer) check(i int) {
+	if r.err == nil && (i < 0 || i >= len(r.arr)) {
+		r.err = fmt.Errorf("index %d is out of range for %d items", i, len(r.arr))
+	}
+}
+
+func (r *replayer) Compare(i, j int) { r.check(i); r.check(j) }
+func (r *replayer) Pivot(i int)      { r.check(i) }
+func (r *replayer) Range(lo, hi int) { r.check(lo); r.check(hi) }
+
+// Boundary can be just past the last item.
+func (r *replayer) Boundary(i int) {
+	if i != len(r.arr) {
+		r.check(i)
+	}
+}
+
+func (r *replayer) Swap(i, j int) {
+	r.check(i)
+	r.check(j)
+	if r.err == nil {
+		r.arr[i], r.arr[j] = r.arr[j], r.arr[i]
+	}
+}
+
+// check_replay sorts arr with the sort called name, tracing it, and checks
+// that replaying the reported swaps sorts a copy the same way.
+func check_replay(name string, arr []int, less func(a, b int) bool) error {
+	r := &replayer{arr: append([]int(nil), arr...)}
+	sorter, _ := ByName[int](name)
+	sorter.SortStats(arr, less, &stats.Stats{Tracer: r})
+	if r.err != nil {
+		return fmt.Errorf("%s: %v", name, r.err)
+	}
+	if !testutil.Equal(r.arr, arr) {
+		return fmt.Errorf("%s: replaying the swaps gave %s, not %s", name, testutil.Describe(r.arr), testutil.Describe(arr))
+	}
+	return nil
+}
+
+// TestTracedSortsReplay checks that the sorts which report their steps to a
+// Tracer report every swap, at the right index.
+func TestTracedSortsReplay(t *testing.T) {
+	names := []string{"bubble_sort", "cocktail_shaker_sort", "comb_sort", "gnome_sort",
+		"heap_sort", "insertion_sort", "quicksort", "quicksort_lomuto"}
+	testutil.RunRounds(t, func(r *datagen.Generator, max_len int) error {
+		arr := r.Mixed(max_len)
+		for _, name := range names {
+			if err := check_replay(name, append([]int(nil), arr...), Less[int]); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+
+	// The adversary drives quicksort into its heapsort fallback on parts of
+	// the slice that don't start at 0.
+	less, _ := adversary(1000)
+	if err := check_replay("quicksort", datagen.Sorted(1000), less); err != nil {
+		t.Fatal(err)
+	}
+}
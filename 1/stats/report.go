@@ -0,0 +1,71 @@
+package stats
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+)
+
+// Report is a snapshot of Stats labelled with what was measured.
+type Report struct {
+	// Algorithm names the algorithm that was run.
+	Algorithm string `json:"algorithm"`
+	// Items is the size of the input.
+	Items int `json:"items"`
+	Stats
+}
+
+// MakeReport labels a snapshot of s.
+func MakeReport(algorithm string, items int, s *Stats) Report {
+	return Report{Algorithm: algorithm, Items: items, Stats: s.Snapshot()}
+}
+
+// String formats r as a single line of text.
+func (r Report) String() string {
+	return fmt.Sprintf("%s (%d items): %d comparisons, %d swaps, %d writes, %d probes, %d calls, max depth %d, %d allocations",
+		r.Algorithm, r.Items, r.Comparisons, r.Swaps, r.Writes, r.Probes, r.Calls, r.MaxDepth, r.Allocations)
+}
+
+// CSVHeader returns the column names used by CSVRecord.
+func CSVHeader() []string {
+	return []string{"algorithm", "items", "comparisons", "swaps", "writes", "probes", "calls", "max_depth", "allocations"}
+}
+
+// CSVRecord returns r as a row of the columns named by CSVHeader.
+func (r Report) CSVRecord() []string {
+	record := []string{r.Algorithm, strconv.Itoa(r.Items)}
+	for _, v := range []int64{r.Comparisons, r.Swaps, r.Writes, r.Probes, r.Calls, r.MaxDepth, r.Allocations} {
+		record = append(record, strconv.FormatInt(v, 10))
+	}
+	return record
+}
+
+// WriteReports writes reports to w in the given format: "text" writes one line
+// per report, "json" writes a JSON array and "csv" writes a header row followed
+// by one row per report.
+func WriteReports(w io.Writer, format string, reports []Report) error {
+	switch format {
+	case "text":
+		for _, r := range reports {
+			if _, err := fmt.Fprintln(w, r); err != nil {
+				return err
+			}
+		}
+		return nil
+	case "json":
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(reports)
+	case "csv":
+		writer := csv.NewWriter(w)
+		writer.Write(CSVHeader())
+		for _, r := range reports {
+			writer.Write(r.CSVRecord())
+		}
+		writer.Flush()
+		return writer.Error()
+	}
+	return fmt.Errorf("stats: unknown report format %q", format)
+}
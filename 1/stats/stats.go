@@ -0,0 +1,140 @@
+// Package stats counts the work done by the algorithms so that reports from
+// any of them can be compared side by side.
+//
+// A nil *Stats is valid and counts nothing, so an algorithm can record its
+// work unconditionally and callers that don't care simply pass nil. The
+// counters are updated atomically, so one Stats can be shared by goroutines.
+//
+// A Stats can also carry a Tracer, which the simpler sorts tell about each
+// step they take so that the trace package can replay them.
+package stats
+
+import "sync/atomic"
+
+// Stats counts the basic operations performed by an algorithm.
+type Stats struct {
+	// Comparisons counts calls to a less function or other key comparisons.
+	Comparisons int64 `json:"comparisons"`
+	// Swaps counts exchanges of two items.
+	Swaps int64 `json:"swaps"`
+	// Writes counts single items stored into an array other than by swaps,
+	// such as the shifts of insertion sort or the copies made by a merge.
+	Writes int64 `json:"writes"`
+	// Probes counts items or slots examined by a search.
+	Probes int64 `json:"probes"`
+	// Calls counts recursive calls, including the first one.
+	Calls int64 `json:"calls"`
+	// MaxDepth is the deepest recursion seen, counting the first call as depth 1.
+	MaxDepth int64 `json:"max_depth"`
+	// Allocations counts items allocated for scratch space.
+	Allocations int64 `json:"allocations"`
+
+	// Tracer, if it is not nil, is told about every step of the sorts that
+	// report their steps. See Tracer.
+	Tracer Tracer `json:"-"`
+}
+
+// Compare records one comparison.
+func (s *Stats) Compare() {
+	if s != nil {
+		atomic.AddInt64(&s.Comparisons, 1)
+	}
+}
+
+// Swap records one swap.
+func (s *Stats) Swap() {
+	if s != nil {
+		atomic.AddInt64(&s.Swaps, 1)
+	}
+}
+
+// Write records n item writes.
+func (s *Stats) Write(n int) {
+	if s != nil {
+		atomic.AddInt64(&s.Writes, int64(n))
+	}
+}
+
+// Probe records n probes.
+func (s *Stats) Probe(n int) {
+	if s != nil {
+		atomic.AddInt64(&s.Probes, int64(n))
+	}
+}
+
+// Alloc records the allocation of n items of scratch space.
+func (s *Stats) Alloc(n int) {
+	if s != nil {
+		atomic.AddInt64(&s.Allocations, int64(n))
+	}
+}
+
+// Call records a call made at the given recursion depth, where the first call has depth 1.
+func (s *Stats) Call(depth int) {
+	if s == nil {
+		return
+	}
+	atomic.AddInt64(&s.Calls, 1)
+	s.raise_max_depth(int64(depth))
+}
+
+// raise_max_depth raises MaxDepth to depth unless another goroutine has
+// already raised it further.
+func (s *Stats) raise_max_depth(depth int64) {
+	for {
+		max := atomic.LoadInt64(&s.MaxDepth)
+		if depth <= max || atomic.CompareAndSwapInt64(&s.MaxDepth, max, depth) {
+			return
+		}
+	}
+}
+
+// Snapshot returns a copy of the counters. It is safe to call while other
+// goroutines are still recording. A nil *Stats returns all zeros.
+func (s *Stats) Snapshot() Stats {
+	if s == nil {
+		return Stats{}
+	}
+	return Stats{
+		Comparisons: atomic.LoadInt64(&s.Comparisons),
+		Swaps:       atomic.LoadInt64(&s.Swaps),
+		Writes:      atomic.LoadInt64(&s.Writes),
+		Probes:      atomic.LoadInt64(&s.Probes),
+		Calls:       atomic.LoadInt64(&s.Calls),
+		MaxDepth:    atomic.LoadInt64(&s.MaxDepth),
+		Allocations: atomic.LoadInt64(&s.Allocations),
+	}
+}
+
+// Reset sets every counter back to zero. It keeps the Tracer.
+func (s *Stats) Reset() {
+	if s != nil {
+		*s = Stats{Tracer: s.Tracer}
+	}
+}
+
+// Add adds the counts in o to s and raises s.MaxDepth to o.MaxDepth.
+func (s *Stats) Add(o *Stats) {
+	if s == nil || o == nil {
+		return
+	}
+	atomic.AddInt64(&s.Comparisons, o.Comparisons)
+	atomic.AddInt64(&s.Swaps, o.Swaps)
+	atomic.AddInt64(&s.Writes, o.Writes)
+	atomic.AddInt64(&s.Probes, o.Probes)
+	atomic.AddInt64(&s.Calls, o.Calls)
+	atomic.AddInt64(&s.Allocations, o.Allocations)
+	s.raise_max_depth(o.MaxDepth)
+}
+
+// CountLess wraps less so that every call is recorded as a comparison in s.
+// If s is nil it returns less unchanged, so uninstrumented code pays nothing.
+func CountLess[T any](s *Stats, less func(a, b T) bool) func(a, b T) bool {
+	if s == nil {
+		return less
+	}
+	return func(a, b T) bool {
+		atomic.AddInt64(&s.Comparisons, 1)
+		return less(a, b)
+	}
+}
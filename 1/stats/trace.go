@@ -0,0 +1,76 @@
+package stats
+
+// Tracer is told about each step of a sort as it happens, so that the run can
+// be recorded and replayed. Indices are into the whole slice being sorted.
+//
+// Only some of the sorts report all of their steps. Those move items only by
+// swaps and report every one, so replaying the swaps reproduces the run.
+// The parallel sorts call the Tracer from several goroutines at once.
+type Tracer interface {
+	// Compare is called before the items at i and j are compared.
+	Compare(i, j int)
+	// Swap is called after the items at i and j are swapped.
+	Swap(i, j int)
+	// Pivot is called when the item at i becomes the pivot.
+	Pivot(i int)
+	// Range is called when the sort starts work on the items from lo to hi inclusive.
+	Range(lo, hi int)
+	// Boundary is called when the sort has split the items it is working on
+	// at i: every item before i is no greater than every item from i on that
+	// the sort has looked at so far.
+	Boundary(i int)
+}
+
+// GetTracer returns s's Tracer, or nil if s is nil or has none.
+func (s *Stats) GetTracer() Tracer {
+	if s == nil {
+		return nil
+	}
+	return s.Tracer
+}
+
+// TraceCompare tells the Tracer, if there is one, that the items at i and j
+// are about to be compared. Counting the comparison is left to CountLess.
+func (s *Stats) TraceCompare(i, j int) {
+	if s != nil && s.Tracer != nil {
+		s.Tracer.Compare(i, j)
+	}
+}
+
+// SwapAt records a swap of the items at i and j and tells the Tracer.
+func (s *Stats) SwapAt(i, j int) {
+	// Keep the nil check small enough to inline.
+	if s != nil {
+		s.swap_at(i, j)
+	}
+}
+
+//go:noinline
+func (s *Stats) swap_at(i, j int) {
+	s.Swap()
+	if s.Tracer != nil {
+		s.Tracer.Swap(i, j)
+	}
+}
+
+// TracePivot tells the Tracer, if there is one, that the item at i is the pivot.
+func (s *Stats) TracePivot(i int) {
+	if s != nil && s.Tracer != nil {
+		s.Tracer.Pivot(i)
+	}
+}
+
+// TraceRange tells the Tracer, if there is one, that the sort is working on
+// the items from lo to hi inclusive.
+func (s *Stats) TraceRange(lo, hi int) {
+	if s != nil && s.Tracer != nil {
+		s.Tracer.Range(lo, hi)
+	}
+}
+
+// TraceBoundary tells the Tracer, if there is one, about a boundary at i.
+func (s *Stats) TraceBoundary(i int) {
+	if s != nil && s.Tracer != nil {
+		s.Tracer.Boundary(i)
+	}
+}
@@ -0,0 +1,133 @@
+package trace
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"time"
+)
+
+// The number of rows used for the bars when ASCIIOptions.Height is not set.
+const default_ascii_height = 10
+
+// ASCIIOptions control the ASCII renderer.
+type ASCIIOptions struct {
+	// Height is the number of rows the tallest bar takes.
+	Height int
+}
+
+// WriteASCIIFrame draws fr as a row of vertical bars, one per item, with a
+// line of markers underneath and a caption describing the event.
+//
+// Markers: c compared, s swapped, w written, p pivot, - in the current
+// range, and | before the boundary.
+func WriteASCIIFrame(w io.Writer, fr Frame, total int, opts ASCIIOptions) error {
+	height := opts.Height
+	if height <= 0 {
+		height = default_ascii_height
+	}
+	max := 1
+	for _, v := range fr.Arr {
+		if v > max {
+			max = v
+		}
+	}
+
+	var b strings.Builder
+	for row := height; row >= 1; row-- {
+		for _, v := range fr.Arr {
+			// Round bar heights up so every positive item shows.
+			if (v*height+max-1)/max >= row {
+				b.WriteString("## ")
+			} else {
+				b.WriteString("   ")
+			}
+		}
+		b.WriteString("\n")
+	}
+
+	// Label the bars when every value fits under one.
+	if max < 100 && min_value(fr.Arr) >= 0 {
+		for _, v := range fr.Arr {
+			fmt.Fprintf(&b, "%2d ", v)
+		}
+		b.WriteString("\n")
+	}
+
+	for i := range fr.Arr {
+		if i == fr.Boundary {
+			b.WriteString("|")
+		} else {
+			b.WriteString(" ")
+		}
+		b.WriteByte(marker(fr, i))
+		b.WriteString(" ")
+	}
+	// A boundary can be just past the last item.
+	if fr.Boundary == len(fr.Arr) {
+		b.WriteString("|")
+	}
+	b.WriteString("\n")
+	fmt.Fprintf(&b, "step %d/%d: %v\n", fr.Step+1, total, fr.Event)
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+func min_value(arr []int) int {
+	min := 0
+	for _, v := range arr {
+		if v < min {
+			min = v
+		}
+	}
+	return min
+}
+
+// marker returns the character drawn under index i.
+func marker(fr Frame, i int) byte {
+	switch {
+	case fr.marks(i) && fr.Event.Kind == Compare:
+		return 'c'
+	case fr.marks(i) && fr.Event.Kind == Swap:
+		return 's'
+	case fr.marks(i):
+		return 'w'
+	case i == fr.Pivot:
+		return 'p'
+	case i >= fr.Lo && i <= fr.Hi:
+		return '-'
+	}
+	return ' '
+}
+
+// WriteASCII writes every frame of t, separated by blank lines.
+func WriteASCII(w io.Writer, t *Trace, opts ASCIIOptions) error {
+	var err error
+	t.Frames(func(fr Frame) {
+		if err == nil {
+			err = WriteASCIIFrame(w, fr, len(t.Events), opts)
+		}
+		if err == nil {
+			_, err = io.WriteString(w, "\n")
+		}
+	})
+	return err
+}
+
+// Animate plays t in a terminal, redrawing the screen for each frame and
+// pausing for delay between frames.
+func Animate(w io.Writer, t *Trace, delay time.Duration, opts ASCIIOptions) error {
+	var err error
+	t.Frames(func(fr Frame) {
+		if err != nil {
+			return
+		}
+		// Move the cursor home and clear the screen.
+		if _, err = io.WriteString(w, "\x1b[H\x1b[2J"); err == nil {
+			err = WriteASCIIFrame(w, fr, len(t.Events), opts)
+		}
+		time.Sleep(delay)
+	})
+	return err
+}
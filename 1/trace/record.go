@@ -0,0 +1,120 @@
+package trace
+
+import (
+	"fmt"
+	"strings"
+
+	"example.com/m/v2/sorting"
+	"example.com/m/v2/stats"
+)
+
+// The sorts that report every step to a stats.Tracer, including the pivots,
+// the ranges being partitioned and the boundaries.
+var reports_steps = map[string]bool{
+	"bubble_sort":          true,
+	"cocktail_shaker_sort": true,
+	"comb_sort":            true,
+	"gnome_sort":           true,
+	"heap_sort":            true,
+	"insertion_sort":       true,
+	"quicksort":            true,
+	"quicksort_lomuto":     true,
+}
+
+// Record sorts a copy of arr with the algorithm called name and returns the
+// trace of the run.
+//
+// The simple exchange sorts, heapsort and both quicksorts report each step
+// as they take it. Any other registered comparison sort is traced from the outside:
+// before each comparison the array is checked for items that have moved
+// since the last one. Sorts that copy items into a scratch buffer, such as
+// merge sort, show up as Set events, and their comparisons point at where
+// the items currently are in the array.
+func Record(name string, arr []int) (*Trace, error) {
+	t := &Trace{Algorithm: name, Initial: append([]int(nil), arr...)}
+	work := append([]int(nil), arr...)
+
+	switch {
+	case reports_steps[name]:
+		sorter, _ := sorting.ByName[int](name)
+		sorter.SortStats(work, sorting.Less[int], &stats.Stats{Tracer: recorder{t}})
+	case strings.HasPrefix(name, "parallel_"):
+		return nil, fmt.Errorf("trace: cannot trace %s, which sorts concurrently", name)
+	default:
+		sorter, err := sorting.ByName[item](name)
+		if err != nil {
+			return nil, err
+		}
+		record_sorter(sorter, work, t)
+	}
+	return t, nil
+}
+
+func (t *Trace) add(kind Kind, i, j int) {
+	t.Events = append(t.Events, Event{Kind: kind, I: i, J: j})
+}
+
+// recorder is a stats.Tracer that adds the steps it is told about to a Trace.
+type recorder struct {
+	t *Trace
+}
+
+func (r recorder) Compare(i, j int) { r.t.add(Compare, i, j) }
+func (r recorder) Swap(i, j int)    { r.t.add(Swap, i, j) }
+func (r recorder) Pivot(i int)      { r.t.add(Pivot, i, 0) }
+func (r recorder) Range(lo, hi int) { r.t.add(Range, lo, hi) }
+func (r recorder) Boundary(i int)   { r.t.add(Boundary, i, 0) }
+
+// item is an int labelled with where it started, so record_sorter can tell
+// equal items apart.
+type item struct {
+	value, id int
+}
+
+// record_sorter sorts arr with sorter, recording the moves it makes between
+// comparisons.
+func record_sorter(sorter sorting.Sorter[item], arr []int, t *Trace) {
+	items := make([]item, len(arr))
+	for i, v := range arr {
+		items[i] = item{v, i}
+	}
+
+	// shown is the array as the events so far leave it, and position maps
+	// each id to where it is shown.
+	shown := append([]item(nil), items...)
+	position := make([]int, len(items))
+	for i := range position {
+		position[i] = i
+	}
+
+	sync := func() {
+		var changed []int
+		for i := range items {
+			if items[i] != shown[i] {
+				changed = append(changed, i)
+			}
+		}
+		if len(changed) == 2 && items[changed[0]] == shown[changed[1]] && items[changed[1]] == shown[changed[0]] {
+			t.add(Swap, changed[0], changed[1])
+		} else {
+			for _, i := range changed {
+				t.Events = append(t.Events, Event{Kind: Set, I: i, Value: items[i].value})
+			}
+		}
+		for _, i := range changed {
+			shown[i] = items[i]
+			position[items[i].id] = i
+		}
+	}
+
+	sorter.Sort(items, func(a, b item) bool {
+		sync()
+		t.add(Compare, position[a.id], position[b.id])
+		return a.value < b.value
+	})
+	sync()
+
+	for i, it := range items {
+		arr[i] = it.value
+	}
+}
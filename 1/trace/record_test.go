@@ -0,0 +1,94 @@
+package trace
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"testing"
+
+	"example.com/m/v2/datagen"
+	"example.com/m/v2/sorting"
+	"example.com/m/v2/stats"
+)
+
+// TestReplaySorts records each sort that can be traced and checks that
+// replaying its events ends with the array sorted.
+func TestReplaySorts(t *testing.T) {
+	for seed := int64(0); seed < 100; seed++ {
+		arr := datagen.New(seed).Mixed(100)
+		want := append([]int(nil), arr...)
+		sort.Ints(want)
+
+		for _, name := range sorting.Names() {
+			if strings.HasPrefix(name, "parallel_") {
+				continue
+			}
+			tr, err := Record(name, arr)
+			if err != nil {
+				t.Fatalf("seed %d: %v", seed, err)
+			}
+			if err := tr.Validate(); err != nil {
+				t.Fatalf("seed %d: %s: %v", seed, name, err)
+			}
+			if got := tr.Final(); fmt.Sprint(got) != fmt.Sprint(want) {
+				t.Fatalf("seed %d: replaying %s on %v gave %v", seed, name, arr, got)
+			}
+		}
+	}
+}
+
+// TestReportedSteps checks that the sorts which report their steps report
+// every comparison and only move items by the swaps they report.
+func TestReportedSteps(t *testing.T) {
+	for seed := int64(0); seed < 100; seed++ {
+		arr := datagen.New(seed).Mixed(100)
+		for name := range reports_steps {
+			tr, err := Record(name, arr)
+			if err != nil {
+				t.Fatalf("seed %d: %v", seed, err)
+			}
+
+			var counts stats.Stats
+			sorter, _ := sorting.ByName[int](name)
+			sorter.SortStats(append([]int(nil), arr...), sorting.Less[int], &counts)
+
+			compares, swaps := 0, 0
+			for _, e := range tr.Events {
+				switch e.Kind {
+				case Compare:
+					compares++
+				case Swap:
+					swaps++
+				case Set:
+					t.Fatalf("seed %d: %s reported a set", seed, name)
+				}
+			}
+			if int64(compares) != counts.Comparisons || int64(swaps) != counts.Swaps {
+				t.Fatalf("seed %d: %s reported %d comparisons and %d swaps but made %d and %d",
+					seed, name, compares, swaps, counts.Comparisons, counts.Swaps)
+			}
+		}
+	}
+}
+
+func TestValidate(t *testing.T) {
+	initial := []int{3, 1, 2}
+	for _, e := range []Event{
+		{Kind: Compare, I: 0, J: 3},
+		{Kind: Swap, I: -1, J: 0},
+		{Kind: Set, I: 3, Value: 1},
+		{Kind: Pivot, I: 5},
+		{Kind: Range, I: 2, J: 1},
+		{Kind: Boundary, I: 4},
+	} {
+		tr := &Trace{Initial: initial, Events: []Event{{Kind: Swap, I: 0, J: 1}, e}}
+		if err := tr.Validate(); err == nil {
+			t.Errorf("Validate accepted %v on %d items", e, len(initial))
+		}
+	}
+
+	tr := &Trace{Initial: initial, Events: []Event{{Kind: Range, I: 0, J: 2}, {Kind: Boundary, I: 3}}}
+	if err := tr.Validate(); err != nil {
+		t.Error(err)
+	}
+}
@@ -0,0 +1,90 @@
+package trace
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+var update = flag.Bool("update", false, "rewrite the golden files in testdata")
+
+// record_short records quicksort_lomuto on a few items. Its trace has every
+// kind of event the renderers mark except Set.
+func record_short(t *testing.T) *Trace {
+	tr, err := Record("quicksort_lomuto", []int{4, 1, 3, 5, 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return tr
+}
+
+// check_golden compares got with testdata/name, or rewrites the file if the
+// test is run with -update.
+func check_golden(t *testing.T, name string, got []byte) {
+	t.Helper()
+	path := filepath.Join("testdata", name)
+	if *update {
+		if err := os.WriteFile(path, got, 0o644); err != nil {
+			t.Fatal(err)
+		}
+		return
+	}
+	want, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(got, want) {
+		t.Errorf("output differs from %s (run with -update to rewrite it):\ngot:\n%s\nwant:\n%s", path, got, want)
+	}
+}
+
+func TestWriteASCII(t *testing.T) {
+	var b bytes.Buffer
+	if err := WriteASCII(&b, record_short(t), ASCIIOptions{Height: 5}); err != nil {
+		t.Fatal(err)
+	}
+	check_golden(t, "quicksort_lomuto.txt", b.Bytes())
+}
+
+// TestWriteASCIISet checks the marker for a Set event and a boundary just
+// past the last item, which the recorded trace doesn't have.
+func TestWriteASCIISet(t *testing.T) {
+	tr := &Trace{
+		Initial: []int{2, 1},
+		Events:  []Event{{Kind: Set, I: 0, Value: 1}, {Kind: Boundary, I: 2}},
+	}
+	var b bytes.Buffer
+	if err := WriteASCII(&b, tr, ASCIIOptions{Height: 2}); err != nil {
+		t.Fatal(err)
+	}
+	want := "## ## \n## ## \n 1  1 \n w    \nstep 1/2: set 0 = 1\n\n" +
+		"## ## \n## ## \n 1  1 \n      |\nstep 2/2: boundary 2\n\n"
+	if got := b.String(); got != want {
+		t.Errorf("got:\n%q\nwant:\n%q", got, want)
+	}
+}
+
+func TestWriteSVGFrames(t *testing.T) {
+	tr := record_short(t)
+	dir := t.TempDir()
+	n, err := WriteSVGFrames(dir, tr, SVGOptions{Width: 120, Height: 80})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n != len(tr.Events) {
+		t.Fatalf("wrote %d frames for %d events", n, len(tr.Events))
+	}
+
+	var b bytes.Buffer
+	for step := 1; step <= n; step++ {
+		frame, err := os.ReadFile(filepath.Join(dir, fmt.Sprintf("frame_%04d.svg", step)))
+		if err != nil {
+			t.Fatal(err)
+		}
+		b.Write(frame)
+	}
+	check_golden(t, "quicksort_lomuto.svg", b.Bytes())
+}
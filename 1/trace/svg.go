@@ -0,0 +1,123 @@
+package trace
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// The size of an SVG frame when SVGOptions leaves it unset.
+const (
+	default_svg_width  = 640
+	default_svg_height = 360
+)
+
+// Space reserved under the bars for the caption.
+const svg_caption_height = 30
+
+// Bar colours.
+const (
+	color_plain    = "#9db4c0"
+	color_range    = "#4a6fa5"
+	color_compared = "#f2a541"
+	color_moved    = "#d1495b"
+	color_pivot    = "#7b2d8b"
+)
+
+// SVGOptions control the SVG renderer.
+type SVGOptions struct {
+	// Width and Height are the size of each frame in pixels.
+	Width, Height int
+}
+
+// WriteSVGFrame draws fr as an SVG image of vertical bars. Compared items are
+// orange, swapped or written items red, the pivot purple and the current
+// range darker than the rest. The boundary is a dashed line.
+func WriteSVGFrame(w io.Writer, fr Frame, total int, opts SVGOptions) error {
+	width, height := opts.Width, opts.Height
+	if width <= 0 {
+		width = default_svg_width
+	}
+	if height <= 0 {
+		height = default_svg_height
+	}
+	plot_height := float64(height - svg_caption_height)
+	max := 1
+	for _, v := range fr.Arr {
+		if v > max {
+			max = v
+		}
+	}
+	bar_width := float64(width) / float64(len(fr.Arr)+1)
+
+	var b strings.Builder
+	fmt.Fprintf(&b, `<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="%d" viewBox="0 0 %d %d">`+"\n",
+		width, height, width, height)
+	fmt.Fprintf(&b, `<rect width="%d" height="%d" fill="white"/>`+"\n", width, height)
+	for i, v := range fr.Arr {
+		bar_height := 0.0
+		if v > 0 {
+			bar_height = plot_height * float64(v) / float64(max)
+		}
+		x := bar_width * (float64(i) + 0.5)
+		fmt.Fprintf(&b, `<rect x="%.1f" y="%.1f" width="%.1f" height="%.1f" fill="%s"/>`+"\n",
+			x, plot_height-bar_height, bar_width*0.9, bar_height, bar_color(fr, i))
+	}
+	if fr.Boundary >= 0 {
+		x := bar_width * (float64(fr.Boundary) + 0.45)
+		fmt.Fprintf(&b, `<line x1="%.1f" y1="0" x2="%.1f" y2="%.1f" stroke="black" stroke-dasharray="4 3"/>`+"\n",
+			x, x, plot_height)
+	}
+	fmt.Fprintf(&b, `<text x="8" y="%d" font-family="monospace" font-size="16">step %d/%d: %v</text>`+"\n",
+		height-8, fr.Step+1, total, fr.Event)
+	b.WriteString("</svg>\n")
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// bar_color returns the colour of the bar for index i.
+func bar_color(fr Frame, i int) string {
+	switch {
+	case fr.marks(i) && fr.Event.Kind == Compare:
+		return color_compared
+	case fr.marks(i):
+		return color_moved
+	case i == fr.Pivot:
+		return color_pivot
+	case i >= fr.Lo && i <= fr.Hi:
+		return color_range
+	}
+	return color_plain
+}
+
+// WriteSVGFrames writes one SVG file per frame of t into dir, named
+// frame_0001.svg, frame_0002.svg and so on, and returns the number written.
+func WriteSVGFrames(dir string, t *Trace, opts SVGOptions) (int, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return 0, err
+	}
+
+	num_written := 0
+	var err error
+	t.Frames(func(fr Frame) {
+		if err != nil {
+			return
+		}
+		var f *os.File
+		f, err = os.Create(filepath.Join(dir, fmt.Sprintf("frame_%04d.svg", fr.Step+1)))
+		if err != nil {
+			return
+		}
+		err = WriteSVGFrame(f, fr, len(t.Events), opts)
+		if close_err := f.Close(); err == nil {
+			err = close_err
+		}
+		if err == nil {
+			num_written++
+		}
+	})
+	return num_written, err
+}
@@ -0,0 +1,182 @@
+// Package trace records what a sort does one step at a time, so a run can be
+// replayed and inspected, and renders the steps as an ASCII animation or a
+// sequence of SVG frames.
+package trace
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// Kind says what happened in an Event.
+type Kind int
+
+const (
+	// Compare means the items at I and J were compared.
+	Compare Kind = iota
+	// Swap means the items at I and J were swapped.
+	Swap
+	// Set means Value was written to index I.
+	Set
+	// Pivot means the item at I was chosen as the pivot.
+	Pivot
+	// Range means the sort is now working on the items from I to J inclusive.
+	Range
+	// Boundary means the sort has split the items it is working on at I:
+	// every item before I is no greater than every item from I on that it
+	// has looked at so far. In a partition the items before I are no greater
+	// than the pivot, and in bubble sort the items from I on are the largest.
+	Boundary
+)
+
+var kind_names = []string{"compare", "swap", "set", "pivot", "range", "boundary"}
+
+func (k Kind) String() string {
+	if k >= 0 && int(k) < len(kind_names) {
+		return kind_names[k]
+	}
+	return fmt.Sprintf("Kind(%d)", int(k))
+}
+
+// MarshalJSON writes k as its name.
+func (k Kind) MarshalJSON() ([]byte, error) {
+	return json.Marshal(k.String())
+}
+
+// UnmarshalJSON reads k from its name.
+func (k *Kind) UnmarshalJSON(data []byte) error {
+	var name string
+	if err := json.Unmarshal(data, &name); err != nil {
+		return err
+	}
+	for i, n := range kind_names {
+		if n == name {
+			*k = Kind(i)
+			return nil
+		}
+	}
+	return fmt.Errorf("trace: unknown event kind %q", name)
+}
+
+// Event is one step of a sort.
+type Event struct {
+	Kind  Kind `json:"kind"`
+	I     int  `json:"i"`
+	J     int  `json:"j"`
+	Value int  `json:"value,omitempty"`
+}
+
+func (e Event) String() string {
+	switch e.Kind {
+	case Compare, Swap, Range:
+		return fmt.Sprintf("%v %d %d", e.Kind, e.I, e.J)
+	case Set:
+		return fmt.Sprintf("set %d = %d", e.I, e.Value)
+	}
+	return fmt.Sprintf("%v %d", e.Kind, e.I)
+}
+
+// Trace is a recorded sort: the items it started with and every step it took.
+type Trace struct {
+	Algorithm string  `json:"algorithm"`
+	Initial   []int   `json:"initial"`
+	Events    []Event `json:"events"`
+}
+
+// Validate checks that every event points at items in the array, so that
+// the trace can be replayed. A trace read from a file should be validated
+// first.
+func (t *Trace) Validate() error {
+	n := len(t.Initial)
+	in_range := func(i int) bool { return i >= 0 && i < n }
+	for step, e := range t.Events {
+		ok := false
+		switch e.Kind {
+		case Compare, Swap:
+			ok = in_range(e.I) && in_range(e.J)
+		case Set, Pivot:
+			ok = in_range(e.I)
+		case Range:
+			ok = in_range(e.I) && in_range(e.J) && e.I <= e.J
+		case Boundary:
+			// A boundary can be just past the last item.
+			ok = e.I >= 0 && e.I <= n
+		}
+		if !ok {
+			return fmt.Errorf("trace: event %d (%v) is out of range for %d items", step, e, n)
+		}
+	}
+	return nil
+}
+
+// Replay calls f with the array as it is after each event. The array is
+// reused between calls, so f must copy it to keep it. The trace must be
+// valid; see Validate.
+func (t *Trace) Replay(f func(step int, arr []int, e Event)) {
+	arr := append([]int(nil), t.Initial...)
+	for step, e := range t.Events {
+		switch e.Kind {
+		case Swap:
+			arr[e.I], arr[e.J] = arr[e.J], arr[e.I]
+		case Set:
+			arr[e.I] = e.Value
+		}
+		f(step, arr, e)
+	}
+}
+
+// Final returns the array as it is after the last event.
+func (t *Trace) Final() []int {
+	arr := append([]int(nil), t.Initial...)
+	t.Replay(func(step int, a []int, e Event) {
+		if step == len(t.Events)-1 {
+			copy(arr, a)
+		}
+	})
+	return arr
+}
+
+// Frame is the state of a sort after one event, as the renderers draw it.
+type Frame struct {
+	// Step counts the events from 0.
+	Step int
+	// Arr is the array after the event. It is reused between frames.
+	Arr   []int
+	Event Event
+	// Lo and Hi are the range being worked on, or -1 if there is none.
+	Lo, Hi int
+	// Pivot is the index of the current pivot, or -1 if there is none.
+	Pivot int
+	// Boundary is the last boundary reported in the current range, or -1.
+	Boundary int
+}
+
+// Frames calls f with the frame after each event. Ranges, pivots and
+// boundaries last until the next event that replaces them; a new range
+// clears the pivot and boundary.
+func (t *Trace) Frames(f func(fr Frame)) {
+	fr := Frame{Lo: -1, Hi: -1, Pivot: -1, Boundary: -1}
+	t.Replay(func(step int, arr []int, e Event) {
+		switch e.Kind {
+		case Range:
+			fr.Lo, fr.Hi, fr.Pivot, fr.Boundary = e.I, e.J, -1, -1
+		case Pivot:
+			fr.Pivot = e.I
+		case Boundary:
+			fr.Boundary = e.I
+		}
+		fr.Step, fr.Arr, fr.Event = step, arr, e
+		f(fr)
+	})
+}
+
+// marks reports whether the event in fr points at index i.
+func (fr Frame) marks(i int) bool {
+	switch fr.Event.Kind {
+	case Compare, Swap:
+		return i == fr.Event.I || i == fr.Event.J
+	case Set:
+		return i == fr.Event.I
+	}
+	return false
+}
@@ -0,0 +1,55 @@
+package main
+
+import (
+	"fmt"
+
+	"example.com/m/2/lists"
+)
+
+func main() {
+	// Test queue functions.
+	fmt.Printf("*** Queue Functions ***\n")
+	queue := lists.NewDoublyLinkedList[string]()
+	queue.Enqueue("Agate")
+	queue.Enqueue("Beryl")
+	fmt.Printf("%s ", queue.Dequeue())
+	queue.Enqueue("Citrine")
+	fmt.Printf("%s ", queue.Dequeue())
+	fmt.Printf("%s ", queue.Dequeue())
+	queue.Enqueue("Diamond")
+	queue.Enqueue("Emerald")
+	for !queue.IsEmpty() {
+		fmt.Printf("%s ", queue.Dequeue())
+	}
+	fmt.Printf("\n\n")
+
+	// Test deque functions. Names starting
+	// with F have a fast pass.
+	fmt.Printf("*** Deque Functions ***\n")
+	deque := lists.NewDoublyLinkedList[string]()
+	deque.PushTop("Ann")
+	deque.PushTop("Ben")
+	fmt.Printf("%s ", deque.PopBottom())
+	deque.PushBottom("F-Cat")
+	fmt.Printf("%s ", deque.PopBottom())
+	fmt.Printf("%s ", deque.PopBottom())
+	deque.PushBottom("F-Dan")
+	deque.PushTop("Eva")
+	for !deque.IsEmpty() {
+		fmt.Printf("%s ", deque.PopBottom())
+	}
+	fmt.Printf("\n\n")
+
+	// Test list functions.
+	fmt.Printf("*** List Functions ***\n")
+	gems := lists.NewDoublyLinkedListFrom([]string{"Garnet", "Agate", "Jade"})
+	more := lists.NewDoublyLinkedListFrom([]string{"Beryl", "Opal"})
+	gems.Splice(1, more)
+	gems.InsertAt(gems.Length(), "Citrine")
+	lists.RemoveValue(gems, "Opal")
+	fmt.Printf("%s\n", gems.ToString(" "))
+	gems.Sort(func(a, b string) bool { return a < b })
+	fmt.Printf("%s\n", gems.ToString(" "))
+	gems.Reverse()
+	fmt.Printf("%s (%d gems)\n", gems.ToString(" "), gems.Length())
+}
@@ -0,0 +1,399 @@
+package lists
+
+import "fmt"
+
+type doubly_cell[T any] struct {
+	data T
+	prev *doubly_cell[T]
+	next *doubly_cell[T]
+}
+
+// DoublyLinkedList is a doubly linked list with sentinels above the first
+// cell and below the last, so it works as a stack, queue or deque.
+type DoublyLinkedList[T any] struct {
+	top_sentinel    *doubly_cell[T]
+	bottom_sentinel *doubly_cell[T]
+	count           int
+}
+
+// NewDoublyLinkedList returns an empty list.
+func NewDoublyLinkedList[T any]() *DoublyLinkedList[T] {
+	// Create the sentinels.
+	top_sentinel := doubly_cell[T]{prev: nil, next: nil}
+	bottom_sentinel := doubly_cell[T]{prev: nil, next: nil}
+
+	// Make them point to each other.
+	top_sentinel.next = &bottom_sentinel
+	bottom_sentinel.prev = &top_sentinel
+
+	return &DoublyLinkedList[T]{top_sentinel: &top_sentinel, bottom_sentinel: &bottom_sentinel}
+}
+
+// NewDoublyLinkedListFrom returns a list holding values, in order from top to bottom.
+func NewDoublyLinkedListFrom[T any](values []T) *DoublyLinkedList[T] {
+	list := NewDoublyLinkedList[T]()
+	list.AddRange(values)
+	return list
+}
+
+// Add a cell immadiately after me.
+func (me *doubly_cell[T]) add_after(after *doubly_cell[T]) {
+	other := (*me).next
+
+	// The ordering should now be: me, after, other
+
+	after.next = other
+	after.prev = me
+
+	me.next = after
+	other.prev = after
+}
+
+// Add a cell immediately before me.
+func (me *doubly_cell[T]) add_before(before *doubly_cell[T]) {
+	// This is equivalent to adding this cell immedaitely after my prev.
+	me.prev.add_after(before)
+}
+
+// Delete me.
+func (me *doubly_cell[T]) delete() doubly_cell[T] {
+	if me.next == nil || me.prev == nil {
+		panic("no cell after me, or no cell before me")
+	}
+
+	me.prev.next = me.next
+	me.next.prev = me.prev
+
+	return *me
+}
+
+// AddRange adds values to the bottom of the list, in order.
+func (list *DoublyLinkedList[T]) AddRange(values []T) {
+	// iterate through the values to be added
+	for _, value := range values {
+		// create a new cell
+		newCell := doubly_cell[T]{data: value}
+		// use add_before to add that cell before the bottom sentinel
+		list.bottom_sentinel.add_before(&newCell)
+	}
+	list.count += len(values)
+}
+
+// ToString returns the values, top first, with separator between them.
+func (list *DoublyLinkedList[T]) ToString(separator string) string {
+	output := ""
+
+	// grab the first cell and add its data to the output
+	cell := list.top_sentinel.next
+	// if this cell is in fact the bottom sentinel, return nothing
+	if cell.next == nil {
+		return output
+	}
+	// otherwise, this is a real cell and has data
+	output += fmt.Sprint(cell.data)
+	for {
+		// move onto the next cell
+		cell = cell.next
+
+		// if this cell is in fact the bottom sentinel, break
+		if cell.next == nil {
+			break
+		}
+
+		// otherwise, it is a real cell and has data
+		output += separator
+		output += fmt.Sprint(cell.data)
+	}
+
+	return output
+}
+
+// Length returns the number of values in the list.
+func (list *DoublyLinkedList[T]) Length() int {
+	// the count is kept up to date by every method that adds or removes cells
+	return list.count
+}
+
+// IsEmpty reports whether the list holds no values.
+func (list *DoublyLinkedList[T]) IsEmpty() bool {
+	// the list is empty if the cell after the top sentinel is in fact the bottom sentinel
+	return list.top_sentinel.next.next == nil
+}
+
+// Push adds value to the top of the list.
+func (list *DoublyLinkedList[T]) Push(value T) {
+	// create a new cell to hold the new item
+	newCell := doubly_cell[T]{data: value}
+	// use add_after to add the new cell after the top sentinel
+	sentinel := list.top_sentinel
+	sentinel.add_after(&newCell)
+	list.count++
+}
+
+// Pop removes and returns the value at the top of the list.
+func (list *DoublyLinkedList[T]) Pop() T {
+	return list.remove_cell(list.top_sentinel.next)
+}
+
+// Enqueue adds value to the back of the queue, which is the top of the list.
+func (list *DoublyLinkedList[T]) Enqueue(value T) {
+	list.Push(value)
+}
+
+// Dequeue removes and returns the value at the front of the queue, which is
+// the bottom of the list.
+func (list *DoublyLinkedList[T]) Dequeue() T {
+	// remove the item before the bottom sentinel
+	return list.remove_cell(list.bottom_sentinel.prev)
+}
+
+// PushBottom adds value to the bottom of the list.
+func (list *DoublyLinkedList[T]) PushBottom(value T) {
+	// add an item to the bottom of the list just before the bottom sentinel
+	list.bottom_sentinel.add_before(&doubly_cell[T]{data: value})
+	list.count++
+}
+
+// PushTop adds value to the top of the list.
+func (list *DoublyLinkedList[T]) PushTop(value T) {
+	// add an item to the top of the list just after the top sentinel
+	list.top_sentinel.add_after(&doubly_cell[T]{data: value})
+	list.count++
+}
+
+// PopTop removes and returns the value at the top of the list.
+func (list *DoublyLinkedList[T]) PopTop() T {
+	return list.remove_cell(list.top_sentinel.next)
+}
+
+// PopBottom removes and returns the value at the bottom of the list.
+func (list *DoublyLinkedList[T]) PopBottom() T {
+	return list.remove_cell(list.bottom_sentinel.prev)
+}
+
+// Delete a cell of the list and return its value. Deleting a sentinel
+// panics, so popping an empty list leaves the count alone.
+func (list *DoublyLinkedList[T]) remove_cell(cell *doubly_cell[T]) T {
+	value := cell.delete().data
+	list.count--
+	return value
+}
+
+// Return the cell at position index, where 0 is the top of the list and
+// Length() is the bottom sentinel. It walks from whichever end is nearer.
+func (list *DoublyLinkedList[T]) cell_at(index int) *doubly_cell[T] {
+	if index < 0 || index > list.count {
+		panic(fmt.Sprintf("index %d out of range for a list of length %d", index, list.count))
+	}
+	if index <= list.count/2 {
+		cell := list.top_sentinel.next
+		for i := 0; i < index; i++ {
+			cell = cell.next
+		}
+		return cell
+	}
+	cell := list.bottom_sentinel
+	for i := list.count; i > index; i-- {
+		cell = cell.prev
+	}
+	return cell
+}
+
+// InsertAt inserts value so that it ends up at position index.
+func (list *DoublyLinkedList[T]) InsertAt(index int, value T) {
+	list.cell_at(index).add_before(&doubly_cell[T]{data: value})
+	list.count++
+}
+
+// RemoveAt removes and returns the value at position index.
+func (list *DoublyLinkedList[T]) RemoveAt(index int) T {
+	if index == list.count {
+		panic(fmt.Sprintf("index %d out of range for a list of length %d", index, list.count))
+	}
+	return list.remove_cell(list.cell_at(index))
+}
+
+// FindFunc returns the position of the first value for which match returns true, or -1.
+func (list *DoublyLinkedList[T]) FindFunc(match func(value T) bool) int {
+	index := 0
+	for cell := list.top_sentinel.next; cell.next != nil; cell = cell.next {
+		if match(cell.data) {
+			return index
+		}
+		index++
+	}
+	return -1
+}
+
+// RemoveFunc removes the first value for which match returns true, and
+// reports whether there was one.
+func (list *DoublyLinkedList[T]) RemoveFunc(match func(value T) bool) bool {
+	for cell := list.top_sentinel.next; cell.next != nil; cell = cell.next {
+		if match(cell.data) {
+			list.remove_cell(cell)
+			return true
+		}
+	}
+	return false
+}
+
+// Reverse reverses the list in place by swapping every cell's prev and next pointers.
+func (list *DoublyLinkedList[T]) Reverse() {
+	if list.count == 0 {
+		return
+	}
+	first := list.top_sentinel.next
+	last := list.bottom_sentinel.prev
+	for cell := first; cell != list.bottom_sentinel; {
+		next := cell.next
+		cell.prev, cell.next = cell.next, cell.prev
+		cell = next
+	}
+
+	// the old first and last cells now point at the wrong sentinels
+	first.next = list.bottom_sentinel
+	list.bottom_sentinel.prev = first
+	last.prev = list.top_sentinel
+	list.top_sentinel.next = last
+}
+
+// Splice moves all of other's cells into the list so that the first of them ends up
+// at position index. other is left empty.
+func (list *DoublyLinkedList[T]) Splice(index int, other *DoublyLinkedList[T]) {
+	if other == list {
+		panic("cannot splice a list into itself")
+	}
+	if other.count == 0 {
+		return
+	}
+	after := list.cell_at(index)
+	before := after.prev
+	first := other.top_sentinel.next
+	last := other.bottom_sentinel.prev
+
+	// link other's cells in between before and after
+	before.next = first
+	first.prev = before
+	last.next = after
+	after.prev = last
+	list.count += other.count
+
+	other.top_sentinel.next = other.bottom_sentinel
+	other.bottom_sentinel.prev = other.top_sentinel
+	other.count = 0
+}
+
+// Concat moves all of other's cells to the bottom of the list in O(1) time.
+// other is left empty.
+func (list *DoublyLinkedList[T]) Concat(other *DoublyLinkedList[T]) {
+	list.Splice(list.count, other)
+}
+
+// Sort sorts the list in place with a merge sort that relinks the cells rather
+// than copying values. It is stable, takes O(n log n) time and needs no
+// memory apart from O(log n) stack.
+func (list *DoublyLinkedList[T]) Sort(less func(a, b T) bool) {
+	if list.count < 2 {
+		return
+	}
+
+	// sort the cells as a singly linked chain that ends in nil
+	list.bottom_sentinel.prev.next = nil
+	sorted, _ := sort_doubly_cells(list.top_sentinel.next, list.count, less)
+
+	// then put back the prev pointers and the sentinels
+	prev := list.top_sentinel
+	for cell := sorted; cell != nil; cell = cell.next {
+		prev.next = cell
+		cell.prev = prev
+		prev = cell
+	}
+	prev.next = list.bottom_sentinel
+	list.bottom_sentinel.prev = prev
+}
+
+// Sort the first n cells of the chain starting at head, following only the
+// next pointers. Return the sorted chain, which ends in nil, and the cell
+// that followed the first n.
+func sort_doubly_cells[T any](head *doubly_cell[T], n int, less func(a, b T) bool) (sorted *doubly_cell[T], rest *doubly_cell[T]) {
+	if n == 0 {
+		return nil, head
+	}
+	if n == 1 {
+		rest = head.next
+		head.next = nil
+		return head, rest
+	}
+
+	// sort each half, then merge them
+	left, rest := sort_doubly_cells(head, n/2, less)
+	right, rest := sort_doubly_cells(rest, n-n/2, less)
+	return merge_doubly_cells(left, right, less), rest
+}
+
+// Merge two sorted chains of cells into one. Ties are taken from left first,
+// which keeps the sort stable.
+func merge_doubly_cells[T any](left, right *doubly_cell[T], less func(a, b T) bool) *doubly_cell[T] {
+	// build the merged chain after a temporary sentinel
+	var merged doubly_cell[T]
+	last := &merged
+	for left != nil && right != nil {
+		if less(right.data, left.data) {
+			last.next = right
+			right = right.next
+		} else {
+			last.next = left
+			left = left.next
+		}
+		last = last.next
+	}
+
+	// append whichever chain has cells left over
+	if left != nil {
+		last.next = left
+	} else {
+		last.next = right
+	}
+	return merged.next
+}
+
+// Each calls f for each value from the top of the list to the bottom.
+func (list *DoublyLinkedList[T]) Each(f func(value T)) {
+	// the bottom sentinel is the only cell with no next cell
+	for cell := list.top_sentinel.next; cell.next != nil; cell = cell.next {
+		f(cell.data)
+	}
+}
+
+// All returns an iterator over the values from the top of the list to the
+// bottom, for use with range. The loop may stop early.
+func (list *DoublyLinkedList[T]) All() func(yield func(value T) bool) {
+	return func(yield func(value T) bool) {
+		for cell := list.top_sentinel.next; cell.next != nil; cell = cell.next {
+			if !yield(cell.data) {
+				return
+			}
+		}
+	}
+}
+
+// Backward returns an iterator over the values from the bottom of the list to the top.
+func (list *DoublyLinkedList[T]) Backward() func(yield func(value T) bool) {
+	return func(yield func(value T) bool) {
+		// the top sentinel is the only cell with no previous cell
+		for cell := list.bottom_sentinel.prev; cell.prev != nil; cell = cell.prev {
+			if !yield(cell.data) {
+				return
+			}
+		}
+	}
+}
+
+// ToSlice copies the values into a new slice, top first.
+func (list *DoublyLinkedList[T]) ToSlice() []T {
+	values := []T{}
+	list.Each(func(value T) {
+		values = append(values, value)
+	})
+	return values
+}
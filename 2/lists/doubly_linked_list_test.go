@@ -0,0 +1,211 @@
+package lists
+
+import (
+	"fmt"
+	"sort"
+	"testing"
+)
+
+// linked reports whether the sentinels point straight at each other.
+func linked[T any](list *DoublyLinkedList[T]) bool {
+	return list.top_sentinel.next == list.bottom_sentinel && list.bottom_sentinel.prev == list.top_sentinel
+}
+
+// consistent reports whether the prev and next pointers agree and the count
+// matches the cells.
+func consistent[T any](list *DoublyLinkedList[T]) bool {
+	count := 0
+	for cell := list.top_sentinel; cell.next != nil; cell = cell.next {
+		if cell.next.prev != cell {
+			return false
+		}
+		if cell.next.next != nil {
+			count++
+		}
+	}
+	return count == list.count && list.Length() == count
+}
+
+var animals = []string{"Ant", "Bat", "Cat", "Dog", "Elk", "Fox"}
+
+func TestDoublyLinkedListEmpty(t *testing.T) {
+	// An empty list is just the two sentinels.
+	list := NewDoublyLinkedList[string]()
+	check_equal(t, "new list ToString", list.ToString(" "), "")
+	check_equal(t, "new list Length", list.Length(), 0)
+	check_equal(t, "new list IsEmpty", list.IsEmpty(), true)
+	check_equal(t, "empty list ToSlice", fmt.Sprint(list.ToSlice()), "[]")
+	check(t, list.ToSlice() != nil, "empty list gives an empty slice")
+	for name, remove := range map[string]func() string{
+		"Pop": list.Pop, "PopTop": list.PopTop, "PopBottom": list.PopBottom, "Dequeue": list.Dequeue,
+	} {
+		check(t, panics(func() { remove() }), name+" on an empty list panics")
+		check(t, linked(list), name+" on an empty list leaves the sentinels alone")
+	}
+	for range list.Backward() {
+		check(t, false, "Backward visits nothing in an empty list")
+	}
+}
+
+func TestDoublyLinkedListEdits(t *testing.T) {
+	list := NewDoublyLinkedList[string]()
+	list.AddRange(animals)
+	check_equal(t, "AddRange", list.ToString(" "), "Ant Bat Cat Dog Elk Fox")
+	check_equal(t, "Length after AddRange", list.Length(), 6)
+	check_equal(t, "IsEmpty after AddRange", list.IsEmpty(), false)
+
+	// Values can be inserted and removed at any position.
+	list.InsertAt(0, "aaa")
+	check_equal(t, "InsertAt the top", list.ToString(" "), "aaa Ant Bat Cat Dog Elk Fox")
+	list.InsertAt(0, "bbb")
+	check_equal(t, "InsertAt before a cell", list.ToString(" "), "bbb aaa Ant Bat Cat Dog Elk Fox")
+	check_equal(t, "RemoveAt(1)", list.RemoveAt(1), "aaa")
+	check_equal(t, "list after RemoveAt(1)", list.ToString(" "), "bbb Ant Bat Cat Dog Elk Fox")
+	check_equal(t, "Length after InsertAt and RemoveAt", list.Length(), 7)
+	check(t, panics(func() { list.top_sentinel.delete() }), "deleting the top sentinel panics")
+	check(t, panics(func() { list.bottom_sentinel.delete() }), "deleting the bottom sentinel panics")
+
+	list.Push("c")
+	check_equal(t, "Push", list.ToString(" "), "c bbb Ant Bat Cat Dog Elk Fox")
+	check_equal(t, "Pop", list.Pop(), "c")
+	check_equal(t, "list after Pop", list.ToString(" "), "bbb Ant Bat Cat Dog Elk Fox")
+
+	// Both ends can be used as a stack, queue or deque down to empty.
+	check_equal(t, "PopBottom", list.PopBottom(), "Fox")
+	check_equal(t, "PopTop", list.PopTop(), "bbb")
+	for !list.IsEmpty() {
+		list.Dequeue()
+	}
+	check(t, linked(list), "emptying the list relinks the sentinels")
+	list.PushBottom("B")
+	list.PushTop("A")
+	check_equal(t, "PushBottom and PushTop", list.ToString(","), "A,B")
+	check_equal(t, "first PopBottom", list.PopBottom(), "B")
+	check_equal(t, "second PopBottom", list.PopBottom(), "A")
+	check(t, linked(list), "PopBottom down to empty relinks the sentinels")
+	list.Enqueue("first")
+	list.Enqueue("second")
+	check_equal(t, "first Dequeue", list.Dequeue(), "first")
+	check_equal(t, "second Dequeue", list.Dequeue(), "second")
+	check(t, linked(list), "Dequeue down to empty relinks the sentinels")
+}
+
+func TestDoublyLinkedListTraversals(t *testing.T) {
+	// Slices round trip, and the traversals agree.
+	list := NewDoublyLinkedListFrom(animals)
+	check_equal(t, "ToSlice", fmt.Sprint(list.ToSlice()), fmt.Sprint(animals))
+	seen := ""
+	for animal := range list.All() {
+		seen += animal
+		if animal == "Bat" {
+			break
+		}
+	}
+	check_equal(t, "All until the loop breaks", seen, "AntBat")
+	seen = ""
+	for animal := range list.Backward() {
+		seen += animal
+	}
+	check_equal(t, "Backward", seen, "FoxElkDogCatBatAnt")
+
+	// The list works with any type.
+	numbers := NewDoublyLinkedListFrom([]int{1, 2, 3})
+	sum := 0
+	numbers.Each(func(n int) { sum += n })
+	check_equal(t, "sum from Each", sum, 6)
+	check_equal(t, "PopBottom from a list of ints", numbers.PopBottom(), 3)
+}
+
+func TestDoublyLinkedListInsertAndRemoveAt(t *testing.T) {
+	// Inserting and removing from either half walks from the nearer end.
+	numbers := NewDoublyLinkedListFrom([]int{1, 3, 5})
+	numbers.InsertAt(3, 6)
+	numbers.InsertAt(2, 4)
+	numbers.InsertAt(1, 2)
+	numbers.InsertAt(0, 0)
+	check_equal(t, "InsertAt", fmt.Sprint(numbers.ToSlice()), "[0 1 2 3 4 5 6]")
+	check(t, consistent(numbers), "InsertAt keeps the links consistent")
+	check(t, panics(func() { numbers.InsertAt(8, 0) }), "InsertAt past the bottom panics")
+	check(t, panics(func() { numbers.InsertAt(-1, 0) }), "InsertAt before the top panics")
+	check(t, panics(func() { numbers.RemoveAt(7) }), "RemoveAt past the bottom panics")
+	check_equal(t, "RemoveAt(6)", numbers.RemoveAt(6), 6)
+	check_equal(t, "RemoveAt(4)", numbers.RemoveAt(4), 4)
+	check_equal(t, "RemoveAt(0)", numbers.RemoveAt(0), 0)
+	check_equal(t, "list after RemoveAt", fmt.Sprint(numbers.ToSlice()), "[1 2 3 5]")
+	check(t, consistent(numbers), "RemoveAt relinks")
+}
+
+func TestDoublyLinkedListFind(t *testing.T) {
+	// Find and remove by value.
+	numbers := NewDoublyLinkedListFrom([]int{7, 8, 7, 9})
+	check_equal(t, "Find(7)", Find(numbers, 7), 0)
+	check_equal(t, "Find(9)", Find(numbers, 9), 3)
+	check_equal(t, "Find(6)", Find(numbers, 6), -1)
+	check_equal(t, "FindFunc", numbers.FindFunc(func(v int) bool { return v > 7 }), 1)
+	check_equal(t, "RemoveValue(7)", RemoveValue(numbers, 7), true)
+	check_equal(t, "list after RemoveValue(7)", fmt.Sprint(numbers.ToSlice()), "[8 7 9]")
+	check_equal(t, "RemoveValue(9)", RemoveValue(numbers, 9), true)
+	check(t, consistent(numbers), "RemoveValue at the bottom relinks")
+	check_equal(t, "PopBottom after RemoveValue(9)", numbers.PopBottom(), 7)
+	check_equal(t, "RemoveValue(6)", RemoveValue(numbers, 6), false)
+	check_equal(t, "Length after RemoveValue of a missing value", numbers.Length(), 1)
+}
+
+func TestDoublyLinkedListReverse(t *testing.T) {
+	// Reverse, including the lists with no cells to swap or only one.
+	for n := 0; n <= 3; n++ {
+		values := []int{1, 2, 3}[:n]
+		numbers := NewDoublyLinkedListFrom(values)
+		numbers.Reverse()
+		backward := []int{}
+		for value := range numbers.Backward() {
+			backward = append(backward, value)
+		}
+		check_equal(t, fmt.Sprintf("Reverse %d items, read backward", n), fmt.Sprint(backward), fmt.Sprint(values))
+		check(t, consistent(numbers), fmt.Sprintf("Reverse %d items keeps the links consistent", n))
+	}
+}
+
+func TestDoublyLinkedListSpliceAndConcat(t *testing.T) {
+	// Splice and Concat move the cells and leave the other list empty.
+	front := NewDoublyLinkedListFrom([]int{1, 4})
+	middle := NewDoublyLinkedListFrom([]int{2, 3})
+	front.Splice(1, middle)
+	check_equal(t, "Splice in the middle", fmt.Sprint(front.ToSlice()), "[1 2 3 4]")
+	check(t, consistent(front), "Splice keeps the links consistent")
+	check(t, middle.IsEmpty() && consistent(middle), "Splice empties the other list")
+	back := NewDoublyLinkedListFrom([]int{5, 6})
+	front.Concat(back)
+	check_equal(t, "Concat", fmt.Sprint(front.ToSlice()), "[1 2 3 4 5 6]")
+	check(t, consistent(front), "Concat keeps the links consistent")
+	check(t, back.IsEmpty() && consistent(back), "Concat empties the other list")
+	front.Concat(back)
+	check_equal(t, "Length after Concat of an empty list", front.Length(), 6)
+	back.Concat(front)
+	check_equal(t, "Concat onto an empty list", fmt.Sprint(back.ToSlice()), "[1 2 3 4 5 6]")
+	check(t, consistent(back), "Concat onto an empty list keeps the links consistent")
+	check(t, panics(func() { back.Concat(back) }), "Concat a list onto itself panics")
+}
+
+func TestDoublyLinkedListSort(t *testing.T) {
+	// Merge sort is stable and relinks both directions.
+	type pair struct{ key, order int }
+	pairs := NewDoublyLinkedListFrom([]pair{{3, 0}, {1, 1}, {2, 2}, {1, 3}, {3, 4}, {2, 5}, {1, 6}})
+	pairs.Sort(func(a, b pair) bool { return a.key < b.key })
+	check_equal(t, "Sort", fmt.Sprint(pairs.ToSlice()), "[{1 1} {1 3} {1 6} {2 2} {2 5} {3 0} {3 4}]")
+	check_equal(t, "PopBottom after Sort", pairs.PopBottom(), pair{3, 4})
+	check_equal(t, "Length after Sort and PopBottom", pairs.Length(), 6)
+	for n := 0; n <= 9; n++ {
+		values := make([]int, n)
+		for i := range values {
+			values[i] = (i * 7) % 5
+		}
+		want := append([]int(nil), values...)
+		sort.Ints(want)
+
+		numbers := NewDoublyLinkedListFrom(values)
+		numbers.Sort(func(a, b int) bool { return a < b })
+		check_equal(t, fmt.Sprintf("Sort %v", values), fmt.Sprint(numbers.ToSlice()), fmt.Sprint(want))
+		check(t, consistent(numbers), fmt.Sprintf("Sort %d items keeps the links consistent", n))
+	}
+}
@@ -0,0 +1,327 @@
+package lists
+
+import "fmt"
+
+type cell[T any] struct {
+	data T
+	next *cell[T]
+}
+
+// LinkedList is a singly linked list with a sentinel before the first cell.
+// It remembers its last cell, so adding to the end takes O(1) time.
+type LinkedList[T any] struct {
+	sentinel *cell[T]
+	// tail is the last cell, or the sentinel if the list is empty
+	tail  *cell[T]
+	count int
+}
+
+// NewLinkedList returns an empty list.
+func NewLinkedList[T any]() *LinkedList[T] {
+	sentinel := cell[T]{next: nil}
+	return &LinkedList[T]{sentinel: &sentinel, tail: &sentinel}
+}
+
+// NewLinkedListFrom returns a list holding values, in order.
+func NewLinkedListFrom[T any](values []T) *LinkedList[T] {
+	list := NewLinkedList[T]()
+	list.AddRange(values)
+	return list
+}
+
+// Add a cell immadiately after me.
+func (me *cell[T]) add_after(after *cell[T]) {
+	after.next = me.next
+	me.next = after
+}
+
+// Delete a cell immadiately after me.
+func (me *cell[T]) delete_after() cell[T] {
+	if me.next == nil {
+		panic("no cell after me")
+	}
+	deleted := *me.next
+	me.next = deleted.next
+	return deleted
+}
+
+// AddRange adds values to the end of the list, in order.
+func (list *LinkedList[T]) AddRange(values []T) {
+	// the tail is the last cell, so there is no need to walk the list to find it
+	last_cell := list.tail
+
+	// iterate through the values to be added
+	for _, value := range values {
+		// create a new cell
+		newCell := cell[T]{data: value}
+		// use add_after to add that cell after last_cell
+		last_cell.add_after(&newCell)
+		// make last_cell point to the new last cell
+		last_cell = &newCell
+	}
+
+	list.tail = last_cell
+	list.count += len(values)
+}
+
+// ToString returns the values, top first, with separator between them.
+func (list *LinkedList[T]) ToString(separator string) string {
+	output := ""
+
+	// top is a pointer to the first cell
+	top := list.sentinel.next
+	for cell := top; cell != nil; cell = cell.next {
+		output += fmt.Sprint(cell.data)
+		// only output a separator if this cell is not the last cell
+		// this loop can be refactored because we are essentially checking the same thing twice (the first time was in the for loop definition)
+		if cell.next != nil {
+			output += separator
+		}
+	}
+
+	return output
+}
+
+// Length returns the number of values in the list.
+func (list *LinkedList[T]) Length() int {
+	// the count is kept up to date by every method that adds or removes cells
+	return list.count
+}
+
+// IsEmpty reports whether the list holds no values.
+func (list *LinkedList[T]) IsEmpty() bool {
+	sentinel := *list.sentinel
+	// the list is empty if the sentinel’s next pointer is nil
+	return sentinel.next == nil
+}
+
+// Push adds value to the top of the list.
+func (list *LinkedList[T]) Push(value T) {
+	// create a new cell to hold the new item
+	newCell := cell[T]{data: value}
+	// use add_after to add the new cell after the sentinel
+	sentinel := list.sentinel
+	sentinel.add_after(&newCell)
+	// the first cell added to an empty list is also the last
+	if list.count == 0 {
+		list.tail = &newCell
+	}
+	list.count++
+}
+
+// Pop removes and returns the value at the top of the list. It panics if
+// the list is empty.
+func (list *LinkedList[T]) Pop() T {
+	// use delete_after to remove the cell from the list
+	sentinel := list.sentinel
+	value := sentinel.delete_after().data
+	list.count--
+	if list.count == 0 {
+		list.tail = sentinel
+	}
+	return value
+}
+
+// Return the cell before position index, where 0 is the top of the list.
+// The cell before the top is the sentinel and the cell before Length() is
+// the tail.
+func (list *LinkedList[T]) cell_before(index int) *cell[T] {
+	if index < 0 || index > list.count {
+		panic(fmt.Sprintf("index %d out of range for a list of length %d", index, list.count))
+	}
+	if index == list.count {
+		return list.tail
+	}
+	cell := list.sentinel
+	for i := 0; i < index; i++ {
+		cell = cell.next
+	}
+	return cell
+}
+
+// InsertAt inserts value so that it ends up at position index.
+func (list *LinkedList[T]) InsertAt(index int, value T) {
+	before := list.cell_before(index)
+	newCell := cell[T]{data: value}
+	before.add_after(&newCell)
+	if before == list.tail {
+		list.tail = &newCell
+	}
+	list.count++
+}
+
+// RemoveAt removes and returns the value at position index.
+func (list *LinkedList[T]) RemoveAt(index int) T {
+	if index == list.count {
+		panic(fmt.Sprintf("index %d out of range for a list of length %d", index, list.count))
+	}
+	before := list.cell_before(index)
+	if before.next == list.tail {
+		list.tail = before
+	}
+	list.count--
+	return before.delete_after().data
+}
+
+// FindFunc returns the position of the first value for which match returns true, or -1.
+func (list *LinkedList[T]) FindFunc(match func(value T) bool) int {
+	index := 0
+	for cell := list.sentinel.next; cell != nil; cell = cell.next {
+		if match(cell.data) {
+			return index
+		}
+		index++
+	}
+	return -1
+}
+
+// RemoveFunc removes the first value for which match returns true, and
+// reports whether there was one.
+func (list *LinkedList[T]) RemoveFunc(match func(value T) bool) bool {
+	// stop at the cell before the match, because that is the cell that changes
+	for before := list.sentinel; before.next != nil; before = before.next {
+		if match(before.next.data) {
+			if before.next == list.tail {
+				list.tail = before
+			}
+			before.delete_after()
+			list.count--
+			return true
+		}
+	}
+	return false
+}
+
+// Reverse reverses the list in place by turning every next pointer around.
+func (list *LinkedList[T]) Reverse() {
+	var reversed *cell[T]
+	first := list.sentinel.next
+	for cell := first; cell != nil; {
+		next := cell.next
+		cell.next = reversed
+		reversed = cell
+		cell = next
+	}
+	list.sentinel.next = reversed
+
+	// the old first cell is the new last cell
+	if first != nil {
+		list.tail = first
+	}
+}
+
+// Splice moves all of other's cells into the list so that the first of them ends up
+// at position index. other is left empty.
+func (list *LinkedList[T]) Splice(index int, other *LinkedList[T]) {
+	if other == list {
+		panic("cannot splice a list into itself")
+	}
+	if other.count == 0 {
+		return
+	}
+	before := list.cell_before(index)
+
+	// link other's chain of cells in between before and before.next
+	other.tail.next = before.next
+	before.next = other.sentinel.next
+	if before == list.tail {
+		list.tail = other.tail
+	}
+	list.count += other.count
+
+	other.sentinel.next = nil
+	other.tail = other.sentinel
+	other.count = 0
+}
+
+// Concat moves all of other's cells to the end of the list in O(1) time.
+// other is left empty.
+func (list *LinkedList[T]) Concat(other *LinkedList[T]) {
+	list.Splice(list.count, other)
+}
+
+// Sort sorts the list in place with a merge sort that relinks the cells rather
+// than copying values. It is stable, takes O(n log n) time and needs no
+// memory apart from O(log n) stack.
+func (list *LinkedList[T]) Sort(less func(a, b T) bool) {
+	sorted, _ := sort_cells(list.sentinel.next, list.count, less)
+	list.sentinel.next = sorted
+
+	// find the new last cell
+	list.tail = list.sentinel
+	for list.tail.next != nil {
+		list.tail = list.tail.next
+	}
+}
+
+// Sort the first n cells of the chain starting at head. Return the sorted
+// chain, which ends in nil, and the cell that followed the first n.
+func sort_cells[T any](head *cell[T], n int, less func(a, b T) bool) (sorted *cell[T], rest *cell[T]) {
+	if n == 0 {
+		return nil, head
+	}
+	if n == 1 {
+		rest = head.next
+		head.next = nil
+		return head, rest
+	}
+
+	// sort each half, then merge them
+	left, rest := sort_cells(head, n/2, less)
+	right, rest := sort_cells(rest, n-n/2, less)
+	return merge_cells(left, right, less), rest
+}
+
+// Merge two sorted chains of cells into one. Ties are taken from left first,
+// which keeps the sort stable.
+func merge_cells[T any](left, right *cell[T], less func(a, b T) bool) *cell[T] {
+	// build the merged chain after a temporary sentinel
+	var merged cell[T]
+	last := &merged
+	for left != nil && right != nil {
+		if less(right.data, left.data) {
+			last.next = right
+			right = right.next
+		} else {
+			last.next = left
+			left = left.next
+		}
+		last = last.next
+	}
+
+	// append whichever chain has cells left over
+	if left != nil {
+		last.next = left
+	} else {
+		last.next = right
+	}
+	return merged.next
+}
+
+// Each calls f for each value from the top of the list down.
+func (list *LinkedList[T]) Each(f func(value T)) {
+	for cell := list.sentinel.next; cell != nil; cell = cell.next {
+		f(cell.data)
+	}
+}
+
+// All returns an iterator over the values from the top of the list down, for use
+// with range. The loop may stop early.
+func (list *LinkedList[T]) All() func(yield func(value T) bool) {
+	return func(yield func(value T) bool) {
+		for cell := list.sentinel.next; cell != nil; cell = cell.next {
+			if !yield(cell.data) {
+				return
+			}
+		}
+	}
+}
+
+// ToSlice copies the values into a new slice, top first.
+func (list *LinkedList[T]) ToSlice() []T {
+	values := []T{}
+	list.Each(func(value T) {
+		values = append(values, value)
+	})
+	return values
+}
@@ -0,0 +1,168 @@
+package lists
+
+import (
+	"fmt"
+	"sort"
+	"testing"
+)
+
+// tail_ok reports whether the tail and count follow the cells.
+func tail_ok[T any](list *LinkedList[T]) bool {
+	last, count := list.sentinel, 0
+	for last.next != nil {
+		last = last.next
+		count++
+	}
+	return list.tail == last && list.count == count
+}
+
+func TestLinkedListEmpty(t *testing.T) {
+	// An empty list is just the sentinel.
+	list := NewLinkedList[int]()
+	check_equal(t, "new list IsEmpty", list.IsEmpty(), true)
+	check_equal(t, "new list Length", list.Length(), 0)
+	check_equal(t, "empty list ToString", list.ToString(" "), "")
+	check_equal(t, "empty list ToSlice", fmt.Sprint(list.ToSlice()), "[]")
+	check(t, list.ToSlice() != nil, "empty list gives an empty slice")
+	check(t, panics(func() { list.Pop() }), "popping an empty list panics")
+	check(t, list.sentinel.next == nil, "failed pop leaves the sentinel alone")
+	list.AddRange(nil)
+	check_equal(t, "IsEmpty after adding nothing", list.IsEmpty(), true)
+
+	// Emptying a list restores the sentinel.
+	list.Push(1)
+	check_equal(t, "Length after a Push", list.Length(), 1)
+	check_equal(t, "Pop", list.Pop(), 1)
+	check_equal(t, "IsEmpty after popping the last item", list.IsEmpty(), true)
+	check(t, list.sentinel.next == nil, "popping the last item clears the sentinel")
+
+	// AddRange appends after the existing items and Push adds at the top.
+	list.AddRange([]int{2, 3})
+	list.AddRange([]int{4})
+	list.Push(1)
+	check_equal(t, "AddRange and Push", list.ToString(","), "1,2,3,4")
+	popped := []int{}
+	for !list.IsEmpty() {
+		popped = append(popped, list.Pop())
+	}
+	check_equal(t, "popped items", fmt.Sprint(popped), "[1 2 3 4]")
+}
+
+func TestLinkedListTraversals(t *testing.T) {
+	// Slices round trip, and the traversals see the same order.
+	words := NewLinkedListFrom([]string{"α", "β", "γ"})
+	check_equal(t, "ToSlice", fmt.Sprint(words.ToSlice()), "[α β γ]")
+	seen := ""
+	words.Each(func(word string) { seen += word })
+	check_equal(t, "Each", seen, "αβγ")
+	seen = ""
+	for word := range words.All() {
+		seen += word
+		if word == "β" {
+			break
+		}
+	}
+	check_equal(t, "All until the loop breaks", seen, "αβ")
+	empty := NewLinkedListFrom([]string{})
+	check_equal(t, "IsEmpty for a list from an empty slice", empty.IsEmpty(), true)
+}
+
+func TestLinkedListInsertAndRemoveAt(t *testing.T) {
+	// Insert and remove at every position, including both ends.
+	numbers := NewLinkedList[int]()
+	numbers.InsertAt(0, 2)
+	numbers.InsertAt(0, 1)
+	numbers.InsertAt(2, 4)
+	numbers.InsertAt(2, 3)
+	check_equal(t, "InsertAt", numbers.ToString(","), "1,2,3,4")
+	check(t, tail_ok(numbers), "InsertAt keeps the tail")
+	check(t, panics(func() { numbers.InsertAt(5, 0) }), "InsertAt past the end panics")
+	check(t, panics(func() { numbers.InsertAt(-1, 0) }), "InsertAt before the top panics")
+	check(t, panics(func() { numbers.RemoveAt(4) }), "RemoveAt past the end panics")
+	check_equal(t, "RemoveAt the end", numbers.RemoveAt(3), 4)
+	check(t, tail_ok(numbers), "RemoveAt the end moves the tail")
+	check_equal(t, "RemoveAt the top", numbers.RemoveAt(0), 1)
+	check(t, tail_ok(numbers), "RemoveAt the top keeps the tail")
+	check_equal(t, "RemoveAt(1)", numbers.RemoveAt(1), 3)
+	check_equal(t, "RemoveAt the last item", numbers.RemoveAt(0), 2)
+	check_equal(t, "IsEmpty after removing every item", numbers.IsEmpty(), true)
+	check(t, numbers.tail == numbers.sentinel, "emptied list's tail is the sentinel")
+	numbers.Push(5)
+	check_equal(t, "Pop the only item", numbers.Pop(), 5)
+	check(t, tail_ok(numbers), "popping the only item resets the tail")
+}
+
+func TestLinkedListFind(t *testing.T) {
+	// Find and remove by value.
+	numbers := NewLinkedListFrom([]int{7, 8, 7, 9})
+	check_equal(t, "Find(7)", Find(numbers, 7), 0)
+	check_equal(t, "Find(9)", Find(numbers, 9), 3)
+	check_equal(t, "Find(6)", Find(numbers, 6), -1)
+	check_equal(t, "FindFunc", numbers.FindFunc(func(v int) bool { return v > 7 }), 1)
+	check_equal(t, "RemoveValue(7)", RemoveValue(numbers, 7), true)
+	check_equal(t, "list after RemoveValue(7)", numbers.ToString(","), "8,7,9")
+	check_equal(t, "RemoveValue(9)", RemoveValue(numbers, 9), true)
+	check(t, tail_ok(numbers), "RemoveValue at the end moves the tail")
+	check_equal(t, "RemoveValue(6)", RemoveValue(numbers, 6), false)
+	check_equal(t, "Length after RemoveValue of a missing value", numbers.Length(), 2)
+}
+
+func TestLinkedListReverse(t *testing.T) {
+	numbers := NewLinkedListFrom([]int{1, 2, 3})
+	numbers.Reverse()
+	check_equal(t, "Reverse", numbers.ToString(","), "3,2,1")
+	check(t, tail_ok(numbers), "Reverse keeps the tail")
+	single := NewLinkedListFrom([]int{1})
+	single.Reverse()
+	check(t, tail_ok(single), "Reverse one item")
+	empty_numbers := NewLinkedList[int]()
+	empty_numbers.Reverse()
+	check_equal(t, "IsEmpty after reversing an empty list", empty_numbers.IsEmpty(), true)
+	check(t, empty_numbers.tail == empty_numbers.sentinel, "Reverse an empty list leaves the tail alone")
+}
+
+func TestLinkedListSpliceAndConcat(t *testing.T) {
+	// Splice and Concat move the cells and leave the other list empty.
+	empty_numbers := NewLinkedList[int]()
+	front := NewLinkedListFrom([]int{1, 4})
+	middle := NewLinkedListFrom([]int{2, 3})
+	front.Splice(1, middle)
+	check_equal(t, "Splice in the middle", front.ToString(","), "1,2,3,4")
+	check(t, tail_ok(front), "Splice keeps the tail")
+	check_equal(t, "Length of the spliced list", middle.Length(), 0)
+	check(t, middle.IsEmpty() && tail_ok(middle), "Splice empties the other list")
+	back := NewLinkedListFrom([]int{5, 6})
+	front.Concat(back)
+	check_equal(t, "Concat", front.ToString(","), "1,2,3,4,5,6")
+	check(t, tail_ok(front), "Concat keeps the tail")
+	check(t, back.IsEmpty() && back.tail == back.sentinel, "Concat empties the other list")
+	front.Concat(back)
+	check_equal(t, "Length after Concat of an empty list", front.Length(), 6)
+	empty_numbers.Concat(front)
+	check_equal(t, "Concat onto an empty list", empty_numbers.ToString(","), "1,2,3,4,5,6")
+	check(t, tail_ok(empty_numbers), "Concat onto an empty list keeps the tail")
+	check(t, panics(func() { empty_numbers.Concat(empty_numbers) }), "Concat a list onto itself panics")
+}
+
+func TestLinkedListSort(t *testing.T) {
+	// Merge sort is stable and keeps the tail.
+	type pair struct{ key, order int }
+	pairs := NewLinkedListFrom([]pair{{3, 0}, {1, 1}, {2, 2}, {1, 3}, {3, 4}, {2, 5}, {1, 6}})
+	pairs.Sort(func(a, b pair) bool { return a.key < b.key })
+	check_equal(t, "Sort", fmt.Sprint(pairs.ToSlice()), "[{1 1} {1 3} {1 6} {2 2} {2 5} {3 0} {3 4}]")
+	check_equal(t, "tail after Sort", pairs.tail.data, pair{3, 4})
+	check_equal(t, "Length after Sort", pairs.Length(), 7)
+	for n := 0; n <= 9; n++ {
+		values := make([]int, n)
+		for i := range values {
+			values[i] = (i * 7) % 5
+		}
+		want := append([]int(nil), values...)
+		sort.Ints(want)
+
+		list := NewLinkedListFrom(values)
+		list.Sort(func(a, b int) bool { return a < b })
+		check_equal(t, fmt.Sprintf("Sort %v", values), fmt.Sprint(list.ToSlice()), fmt.Sprint(want))
+		check(t, tail_ok(list), fmt.Sprintf("Sort %d items keeps the tail", n))
+	}
+}
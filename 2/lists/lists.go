@@ -0,0 +1,25 @@
+// Package lists holds the generic singly and doubly linked lists used by the
+// chapter 2 programs. Both keep sentinel cells so that adding and removing at
+// the ends needs no special cases, and both track their length.
+package lists
+
+// searchable is a list that can report the position of a value.
+type searchable[T any] interface {
+	FindFunc(match func(value T) bool) int
+}
+
+// removable is a list that can remove a value.
+type removable[T any] interface {
+	RemoveFunc(match func(value T) bool) bool
+}
+
+// Find returns the position of the first cell holding value, or -1.
+func Find[T comparable](list searchable[T], value T) int {
+	return list.FindFunc(func(v T) bool { return v == value })
+}
+
+// RemoveValue removes the first cell holding value, and reports whether
+// there was one.
+func RemoveValue[T comparable](list removable[T], value T) bool {
+	return list.RemoveFunc(func(v T) bool { return v == value })
+}
@@ -0,0 +1,28 @@
+package lists
+
+import "testing"
+
+// check_equal fails the test, reporting what and both values, unless got
+// equals want.
+func check_equal[T comparable](t *testing.T, what string, got, want T) {
+	t.Helper()
+	if got != want {
+		t.Errorf("%s: got %v, want %v", what, got, want)
+	}
+}
+
+// check fails the test, reporting what, unless ok is true. It is for
+// conditions such as panics or consistent that have no value to print.
+func check(t *testing.T, ok bool, what string) {
+	t.Helper()
+	if !ok {
+		t.Error(what)
+	}
+}
+
+// panics reports whether f panics.
+func panics(f func()) (panicked bool) {
+	defer func() { panicked = recover() != nil }()
+	f()
+	return false
+}
@@ -0,0 +1,54 @@
+package main
+
+import (
+	"fmt"
+
+	"example.com/m/2/lists"
+)
+
+func main() {
+	// Make a list from a slice of values.
+	greek_letters := []string{
+		"α", "β", "γ", "δ", "ε",
+	}
+	list := lists.NewLinkedList[string]()
+	list.AddRange(greek_letters)
+	fmt.Println(list.ToString(" "))
+	fmt.Println()
+
+	// Demonstrate a stack.
+	stack := lists.NewLinkedList[string]()
+	stack.Push("Apple")
+	stack.Push("Banana")
+	stack.Push("Coconut")
+	stack.Push("Date")
+	for !stack.IsEmpty() {
+		fmt.Printf("Popped: %-7s   Remaining %d: %s\n",
+			stack.Pop(),
+			stack.Length(),
+			stack.ToString(" "))
+	}
+
+	// The list is generic, so it can hold numbers too.
+	squares := lists.NewLinkedListFrom([]int{1, 4, 9, 16, 25})
+	fmt.Printf("\nSquares:")
+	for square := range squares.All() {
+		fmt.Printf(" %d", square)
+	}
+	fmt.Println()
+
+	// Rearrange a list in place.
+	fmt.Println()
+	letters := lists.NewLinkedListFrom([]string{"δ", "β", "ε"})
+	letters.InsertAt(1, "α")
+	fmt.Printf("Inserted α at 1:   %s\n", letters.ToString(" "))
+	more := lists.NewLinkedListFrom([]string{"γ", "ζ"})
+	letters.Concat(more)
+	fmt.Printf("Concatenated γ ζ:  %s\n", letters.ToString(" "))
+	lists.RemoveValue(letters, "ε")
+	fmt.Printf("Removed ε:         %s\n", letters.ToString(" "))
+	letters.Reverse()
+	fmt.Printf("Reversed:          %s\n", letters.ToString(" "))
+	letters.Sort(func(a, b string) bool { return a < b })
+	fmt.Printf("Sorted:            %s   (length %d)\n", letters.ToString(" "), letters.Length())
+}
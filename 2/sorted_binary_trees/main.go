@@ -1,9 +1,13 @@
 package main
 
 import (
+	"flag"
 	"fmt"
 	"log"
+	"os"
 	"strings"
+
+	"example.com/m/v2/cli"
 )
 
 type Node struct {
@@ -162,7 +166,19 @@ func (node *Node) find_value(value string) *Node {
 	}
 }
 
+// result is what the program reports for each target in JSON mode.
+type result struct {
+	Target string `json:"target"`
+	Found  bool   `json:"found"`
+}
+
 func main() {
+	targets := flag.String("targets", "", "comma-separated values to look for (read from stdin if not given)")
+	format := flag.String("format", "text", "output format: text or json")
+	flag.Parse()
+	out, err := cli.NewOutput(os.Stdout, *format)
+	cli.Check(err)
+
 	// Make a root node to act as sentinel.
 	root := Node{"", nil, nil}
 
@@ -181,24 +197,22 @@ func main() {
 	root.insert_value("F")
 
 	// Display the values in sorted order.
-	fmt.Printf("Sorted values: %s\n", root.right.inorder())
+	if !out.JSON() {
+		fmt.Printf("Sorted values: %s\n", root.right.inorder())
+	}
 
 	// Let the user search for values.
-	for {
-		// Get the target value.
-		target := ""
-		fmt.Printf("String: ")
-		fmt.Scanln(&target)
-		if len(target) == 0 {
-			break
-		}
-
+	cli.Check(cli.Stdin().EachString("targets", *targets, "String: ", func(target string) error {
 		// Find the value's node.
 		node := root.find_value(target)
+		if out.JSON() {
+			return out.Record(result{Target: target, Found: node != nil})
+		}
 		if node == nil {
 			fmt.Printf("%s not found\n", target)
 		} else {
 			fmt.Printf("Found value %s\n", target)
 		}
-	}
+		return nil
+	}))
 }
@@ -0,0 +1,150 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"example.com/m/2/lists"
+)
+
+type Node struct {
+	data  string
+	left  *Node
+	right *Node
+}
+
+func build_tree() *Node {
+	a := Node{"A", nil, nil}
+	b := Node{"B", nil, nil}
+	c := Node{"C", nil, nil}
+	d := Node{"D", nil, nil}
+	e := Node{"E", nil, nil}
+	f := Node{"F", nil, nil}
+	g := Node{"G", nil, nil}
+	h := Node{"H", nil, nil}
+	i := Node{"I", nil, nil}
+	j := Node{"J", nil, nil}
+
+	a.left = &b
+	a.right = &c
+
+	b.left = &d
+	b.right = &e
+
+	e.left = &g
+
+	c.right = &f
+	f.left = &h
+
+	h.left = &i
+	h.right = &j
+
+	// return the root node
+	return &a
+}
+
+func (node *Node) display_indented(indent string, depth int) string {
+	result := ""
+
+	// display the given node
+	result += strings.Repeat(indent, depth)
+	result += node.data
+	result += "\n"
+
+	// display the children
+	if node.left != nil {
+		result += node.left.display_indented(indent, depth+1)
+	}
+	if node.right != nil {
+		result += node.right.display_indented(indent, depth+1)
+	}
+
+	return result
+
+}
+
+func (node *Node) preorder() string {
+	result := ""
+
+	// display the given node
+	result += node.data
+
+	// display the children
+	if node.left != nil {
+		result += " " + node.left.preorder()
+	}
+	if node.right != nil {
+		result += " " + node.right.preorder()
+	}
+
+	return result
+}
+
+func (node *Node) inorder() string {
+	result := ""
+
+	if node.left != nil {
+		result += node.left.inorder() + " "
+	}
+
+	result += node.data
+
+	if node.right != nil {
+		result += " " + node.right.inorder()
+	}
+
+	return result
+}
+
+func (node *Node) postorder() string {
+	result := ""
+
+	if node.left != nil {
+		result += node.left.postorder() + " "
+	}
+
+	if node.right != nil {
+		result += node.right.postorder() + " "
+	}
+
+	result += node.data
+
+	return result
+}
+
+func (node *Node) breadth_first() string {
+	result := ""
+	queue := lists.NewDoublyLinkedList[*Node]()
+
+	queue.Enqueue(node)
+
+	for !queue.IsEmpty() {
+		next_node_pointer := queue.Dequeue()
+		result += next_node_pointer.data
+		if next_node_pointer.left != nil {
+			queue.Enqueue(next_node_pointer.left)
+		}
+		if next_node_pointer.right != nil {
+			queue.Enqueue(next_node_pointer.right)
+		}
+		if !queue.IsEmpty() {
+			result += " "
+		}
+	}
+
+	return result
+}
+
+func main() {
+	// Build a tree.
+	a_node := build_tree()
+
+	// Display with indentation.
+	fmt.Println(a_node.display_indented("  ", 0))
+
+	// Display traversals.
+	fmt.Println("Preorder:     ", a_node.preorder())
+	fmt.Println("Inorder:      ", a_node.inorder())
+	fmt.Println("Postorder:    ", a_node.postorder())
+	fmt.Println("Breadth first:", a_node.breadth_first())
+}
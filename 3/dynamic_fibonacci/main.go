@@ -0,0 +1,71 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"strconv"
+
+	"example.com/m/v2/cli"
+)
+
+var fibonacci_values []int64
+
+func fibonacci_on_the_fly(n int64) int64 {
+	// if fibonacci_values is of length at least n+1, then fibonacci_values[n] has been filled in
+	filled_in := int64(len(fibonacci_values)) > n
+
+	// result memoized
+	if filled_in {
+		return fibonacci_values[n]
+	}
+
+	// result not yet memoized
+	result := fibonacci_on_the_fly(n-1) + fibonacci_on_the_fly(n-2)
+	fibonacci_values = append(fibonacci_values, result)
+	return result
+
+}
+
+// fibonacci(92) is the largest Fibonacci number that fits in an int64.
+const max_n = 92
+
+// result is what the program reports for each n in JSON mode.
+type result struct {
+	N         int64 `json:"n"`
+	Fibonacci int64 `json:"fibonacci"`
+}
+
+func main() {
+	n_list := flag.String("n", "", "comma-separated values of n (read from stdin if not given)")
+	format := flag.String("format", "text", "output format: text or json")
+	flag.Parse()
+	out, err := cli.NewOutput(os.Stdout, *format)
+	cli.Check(err)
+
+	// Fill-on-the-fly.
+	fibonacci_values = make([]int64, 2)
+	fibonacci_values[0] = 0
+	fibonacci_values[1] = 1
+
+	cli.Check(cli.Stdin().EachString("n", *n_list, "N: ", func(n_string string) error {
+		// Convert to int and calculate the Fibonacci number.
+		n, err := strconv.ParseInt(n_string, 10, 64)
+		if err != nil || n < 0 || n > max_n {
+			return cli.Invalidf("%q is not an integer between 0 and %d", n_string, max_n)
+		}
+
+		if out.JSON() {
+			return out.Record(result{N: n, Fibonacci: fibonacci_on_the_fly(n)})
+		}
+		fmt.Printf("fibonacci_on_the_fly(%d) = %d\n", n, fibonacci_on_the_fly(n))
+		return nil
+	}))
+
+	// Print out all memoized values just so we can see them.
+	if !out.JSON() {
+		for i := 0; i < len(fibonacci_values); i++ {
+			fmt.Printf("%d: %d\n", i, fibonacci_values[i])
+		}
+	}
+}
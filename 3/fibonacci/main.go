@@ -0,0 +1,51 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"strconv"
+
+	"example.com/m/v2/cli"
+)
+
+func fibonacci(n int64) int64 {
+	// base case
+	if n == 0 {
+		return 0
+	}
+	if n == 1 {
+		return 1
+	}
+
+	// recursive case
+	return fibonacci(n-1) + fibonacci(n-2)
+}
+
+// result is what the program reports for each n in JSON mode.
+type result struct {
+	N         int64 `json:"n"`
+	Fibonacci int64 `json:"fibonacci"`
+}
+
+func main() {
+	n_list := flag.String("n", "", "comma-separated values of n (read from stdin if not given)")
+	format := flag.String("format", "text", "output format: text or json")
+	flag.Parse()
+	out, err := cli.NewOutput(os.Stdout, *format)
+	cli.Check(err)
+
+	cli.Check(cli.Stdin().EachString("n", *n_list, "N: ", func(n_string string) error {
+		// Convert to int and calculate the Fibonacci number.
+		n, err := strconv.ParseInt(n_string, 10, 64)
+		if err != nil || n < 0 {
+			return cli.Invalidf("%q is not a non-negative integer", n_string)
+		}
+
+		if out.JSON() {
+			return out.Record(result{N: n, Fibonacci: fibonacci(n)})
+		}
+		fmt.Printf("fibonacci(%d) = %d\n", n, fibonacci(n))
+		return nil
+	}))
+}
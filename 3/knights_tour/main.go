@@ -3,6 +3,8 @@ package main
 import (
 	"fmt"
 	"time"
+
+	"example.com/m/v2/stats"
 )
 
 // The board dimensions.
@@ -22,8 +24,6 @@ type Offset struct {
 
 var move_offsets []Offset
 
-var num_calls int64
-
 func initialize_offsets() {
 	// SLOW
 	/*
@@ -76,8 +76,9 @@ func dump_board(board [][]int) {
 // Return true or false to indicate whether we have found a solution.
 // board stores cells which correspond the index (starting from 0) of the move when the knight was on this square,
 // or unvisited (-1) if the knight has not yet visited this square
-func find_tour(board [][]int, num_rows, num_cols, cur_row, cur_col, num_visited int) bool {
-	num_calls += 1
+// Each call is counted in s, at a depth equal to the number of squares visited.
+func find_tour(board [][]int, num_rows, num_cols, cur_row, cur_col, num_visited int, s *stats.Stats) bool {
+	s.Call(num_visited)
 
 	if num_visited == num_rows*num_cols {
 		// the knight has previously visited every square
@@ -118,7 +119,7 @@ func find_tour(board [][]int, num_rows, num_cols, cur_row, cur_col, num_visited
 		board[new_row][new_col] = num_visited
 
 		// see if we can find a tour from this new board arrangement
-		tour_result := find_tour(board, num_rows, num_cols, new_row, new_col, num_visited+1)
+		tour_result := find_tour(board, num_rows, num_cols, new_row, new_col, num_visited+1, s)
 
 		// we found a complete tour
 		if tour_result {
@@ -136,8 +137,6 @@ func find_tour(board [][]int, num_rows, num_cols, cur_row, cur_col, num_visited
 }
 
 func main() {
-	num_calls = 0
-
 	// Initialize the move offsets.
 	initialize_offsets()
 
@@ -147,7 +146,8 @@ func main() {
 	// Try to find a tour.
 	start := time.Now()
 	board[0][0] = 0
-	if find_tour(board, num_rows, num_cols, 0, 0, 1) {
+	s := &stats.Stats{}
+	if find_tour(board, num_rows, num_cols, 0, 0, 1, s) {
 		fmt.Println("Success!")
 	} else {
 		fmt.Println("Could not find a tour.")
@@ -155,5 +155,5 @@ func main() {
 	elapsed := time.Since(start)
 	dump_board(board)
 	fmt.Printf("%f seconds\n", elapsed.Seconds())
-	fmt.Printf("%d calls\n", num_calls)
+	fmt.Println(stats.MakeReport("knights_tour", num_rows*num_cols, s))
 }
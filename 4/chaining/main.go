@@ -1,6 +1,10 @@
 package main
 
-import "fmt"
+import (
+	"fmt"
+
+	"example.com/m/v2/stats"
+)
 
 // djb2 hash function. See http://www.cse.yorku.ca/~oz/hash.html.
 func hash(value string) int {
@@ -24,6 +28,8 @@ type Employee struct {
 type ChainingHashTable struct {
 	num_buckets int
 	buckets     [][]*Employee
+	// stats, if not nil, counts the entries examined by find
+	stats *stats.Stats
 }
 
 // Initialize a ChainingHashTable and return a pointer to it.
@@ -51,9 +57,11 @@ func (hash_table *ChainingHashTable) find(name string) (int, int) {
 	bucket_number := hash(name) % hash_table.num_buckets
 	for i, test_employee := range hash_table.buckets[bucket_number] {
 		if test_employee.name == name {
+			hash_table.stats.Probe(i + 1)
 			return bucket_number, i
 		}
 	}
+	hash_table.stats.Probe(len(hash_table.buckets[bucket_number]))
 	return bucket_number, -1
 }
 
@@ -121,10 +129,12 @@ func main() {
 	}
 
 	hash_table := NewChainingHashTable(10)
+	hash_table.stats = &stats.Stats{}
 	for _, employee := range employees {
 		hash_table.set(employee.name, employee.phone)
 	}
 	hash_table.dump()
+	fmt.Println(stats.MakeReport("chaining", len(employees), hash_table.stats))
 
 	fmt.Printf("Table contains Sally Owens: %t\n", hash_table.contains("Sally Owens"))
 	fmt.Printf("Table contains Dan Deever: %t\n", hash_table.contains("Dan Deever"))
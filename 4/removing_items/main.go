@@ -2,8 +2,10 @@ package main
 
 import (
 	"fmt"
-	"math/rand"
 	"strings"
+
+	"example.com/m/v2/datagen"
+	"example.com/m/v2/stats"
 )
 
 // djb2 hash function. See http://www.cse.yorku.ca/~oz/hash.html.
@@ -29,6 +31,8 @@ type Employee struct {
 type LinearProbingHashTable struct {
 	capacity  int
 	employees []*Employee
+	// stats, if not nil, counts the entries examined by find
+	stats *stats.Stats
 }
 
 // Initialize a LinearProbingHashTable and return a pointer to it.
@@ -66,7 +70,9 @@ func (hash_table *LinearProbingHashTable) dump() {
 // Return the key's index or where it would be if present and
 // the probe sequence length.
 // If the key is not present and the table is full, return -1 for the index.
-func (hash_table *LinearProbingHashTable) find(name string) (int, int) {
+func (hash_table *LinearProbingHashTable) find(name string) (index, probe_length int) {
+	defer func() { hash_table.stats.Probe(probe_length) }()
+
 	hash := hash(name) % hash_table.capacity
 	// the index of the first deleted item we come across (if we find one)
 	deleted_index := -1
@@ -295,15 +301,17 @@ func main() {
 	hash_table.probe("Hank Hardy")
 
 	// Look at clustering.
-	random := rand.New(rand.NewSource(12345)) // Initialize with an unchanging seed
+	random := datagen.New(12345) // Initialize with an unchanging seed
 	big_capacity := 1009
 	big_hash_table := NewLinearProbingHashTable(big_capacity)
+	big_hash_table.stats = &stats.Stats{}
 	num_items := int(float32(big_capacity) * 0.9)
 	for i := 0; i < num_items; i++ {
 		str := fmt.Sprintf("%d-%d", i, random.Intn(1000000))
 		big_hash_table.set(str, str)
 	}
 	big_hash_table.dump_concise()
+	fmt.Println(stats.MakeReport("linear_probing", num_items, big_hash_table.stats))
 	fmt.Printf("Average probe sequence length: %f\n",
 		big_hash_table.ave_probe_sequence_length())
 }
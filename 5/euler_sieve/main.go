@@ -1,8 +1,13 @@
 package main
 
 import (
+	"flag"
 	"fmt"
+	"os"
+	"strconv"
 	"time"
+
+	"example.com/m/v2/cli"
 )
 
 // Build a sieve of Eratosthenes.
@@ -111,36 +116,61 @@ func sieve_to_primes(sieve []bool) []int {
 	return result
 }
 
-func main() {
-	var max int
-	fmt.Printf("Max: ")
-	fmt.Scan(&max)
-
-	fmt.Println("eratosthenes")
+// Sieves up to this size are printed in full.
+const max_printed = 1000
 
-	start := time.Now()
-	sieve := sieve_of_eratosthenes(max)
-	elapsed := time.Since(start)
-	fmt.Printf("Elapsed: %f seconds\n", elapsed.Seconds())
-
-	if max <= 1000 {
-		print_sieve(sieve)
+// result is what the program reports for each sieve in JSON mode.
+type result struct {
+	Sieve          string  `json:"sieve"`
+	Max            int     `json:"max"`
+	ElapsedSeconds float64 `json:"elapsed_seconds"`
+	Primes         []int   `json:"primes,omitempty"`
+}
 
-		primes := sieve_to_primes(sieve)
-		fmt.Println(primes)
+func main() {
+	max_list := flag.String("max", "", "comma-separated sieve sizes (read from stdin if not given)")
+	format := flag.String("format", "text", "output format: text or json")
+	flag.Parse()
+	out, err := cli.NewOutput(os.Stdout, *format)
+	cli.Check(err)
+
+	sieves := []struct {
+		name  string
+		build func(max int) []bool
+	}{
+		{"eratosthenes", sieve_of_eratosthenes},
+		{"euler", eulers_sieve},
 	}
 
-	fmt.Println("euler")
+	cli.Check(cli.Stdin().EachString("max", *max_list, "Max: ", func(max_string string) error {
+		max, err := strconv.Atoi(max_string)
+		if err != nil || max < 0 {
+			return cli.Invalidf("%q is not a non-negative integer", max_string)
+		}
 
-	start = time.Now()
-	sieve = eulers_sieve(max)
-	elapsed = time.Since(start)
-	fmt.Printf("Elapsed: %f seconds\n", elapsed.Seconds())
+		for _, s := range sieves {
+			start := time.Now()
+			sieve := s.build(max)
+			elapsed := time.Since(start)
 
-	if max <= 1000 {
-		print_sieve(sieve)
+			r := result{Sieve: s.name, Max: max, ElapsedSeconds: elapsed.Seconds()}
+			if max <= max_printed {
+				r.Primes = sieve_to_primes(sieve)
+			}
+			if out.JSON() {
+				if err := out.Record(r); err != nil {
+					return err
+				}
+				continue
+			}
 
-		primes := sieve_to_primes(sieve)
-		fmt.Println(primes)
-	}
+			fmt.Println(s.name)
+			fmt.Printf("Elapsed: %f seconds\n", elapsed.Seconds())
+			if max <= max_printed {
+				print_sieve(sieve)
+				fmt.Println(r.Primes)
+			}
+		}
+		return nil
+	}))
 }
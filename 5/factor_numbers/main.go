@@ -1,8 +1,13 @@
 package main
 
 import (
+	"flag"
 	"fmt"
+	"os"
+	"strconv"
 	"time"
+
+	"example.com/m/v2/cli"
 )
 
 func find_factors(num int) []int {
@@ -131,33 +136,57 @@ func sieve_to_primes(sieve []bool) []int {
 
 var primes []int
 
+// result is what the program reports for each number in JSON mode.
+type result struct {
+	Number             int     `json:"number"`
+	Factors            []int   `json:"factors"`
+	FindFactorsSeconds float64 `json:"find_factors_seconds"`
+	SieveSeconds       float64 `json:"find_factors_sieve_seconds"`
+}
+
 func main() {
+	numbers := flag.String("numbers", "", "comma-separated numbers to factor (read from stdin if not given)")
+	format := flag.String("format", "text", "output format: text or json")
+	flag.Parse()
+	out, err := cli.NewOutput(os.Stdout, *format)
+	cli.Check(err)
+
 	primes = sieve_to_primes(eulers_sieve(2000000000))
-	for {
-		var num int
-		fmt.Printf("Number to factor: ")
-		fmt.Scan(&num)
 
-		if num < 2 {
-			break
+	cli.Check(cli.Stdin().EachString("numbers", *numbers, "Number to factor: ", func(num_string string) error {
+		num, err := strconv.Atoi(num_string)
+		if err != nil || num < 2 {
+			return cli.Invalidf("%q is not an integer greater than 1", num_string)
 		}
 
 		// Find the factors the slow way.
 		start := time.Now()
 		factors := find_factors(num)
 		elapsed := time.Since(start)
+
+		// Use the Euler's sieve to find the factors.
+		start = time.Now()
+		sieve_factors := find_factors_sieve(num)
+		sieve_elapsed := time.Since(start)
+
+		if out.JSON() {
+			return out.Record(result{
+				Number:             num,
+				Factors:            sieve_factors,
+				FindFactorsSeconds: elapsed.Seconds(),
+				SieveSeconds:       sieve_elapsed.Seconds(),
+			})
+		}
+
 		fmt.Printf("find_factors:       %f seconds\n", elapsed.Seconds())
 		// fmt.Println(multiply_slice(factors))
 		fmt.Println(factors)
 		fmt.Println()
 
-		// Use the Euler's sieve to find the factors.
-		start = time.Now()
-		factors = find_factors_sieve(num)
-		elapsed = time.Since(start)
-		fmt.Printf("find_factors_sieve: %f seconds\n", elapsed.Seconds())
+		fmt.Printf("find_factors_sieve: %f seconds\n", sieve_elapsed.Seconds())
 		// fmt.Println(multiply_slice(factors))
-		fmt.Println(factors)
+		fmt.Println(sieve_factors)
 		fmt.Println()
-	}
+		return nil
+	}))
 }
@@ -0,0 +1,84 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"math"
+	"os"
+	"strconv"
+
+	"example.com/m/v2/cli"
+)
+
+func fast_exp(num, pow int) int {
+	result := 1
+	for pow > 0 {
+		if pow%2 == 1 {
+			result *= num
+		}
+		pow /= 2
+		num *= num
+	}
+	return result
+}
+
+func fast_exp_mod(num, pow, mod int) int {
+	result := 1
+	for pow > 0 {
+		if pow%2 == 1 {
+			result = (result * num) % mod
+		}
+		pow /= 2
+		num = (num * num) % mod
+	}
+	return result
+}
+
+// result is what the program reports for each calculation in JSON mode.
+type result struct {
+	Num        int  `json:"num"`
+	Pow        int  `json:"pow"`
+	Mod        int  `json:"mod"`
+	FastExp    int  `json:"fast_exp"`
+	FastExpMod int  `json:"fast_exp_mod"`
+	SelfCheck  bool `json:"self_check"`
+}
+
+func main() {
+	triples := flag.String("values", "", `comma-separated "num pow mod" triples of positive integers such as "3 4 5,2 10 7" (read from stdin if not given)`)
+	format := flag.String("format", "text", "output format: text or json")
+	flag.Parse()
+	out, err := cli.NewOutput(os.Stdout, *format)
+	cli.Check(err)
+
+	cli.Check(cli.Stdin().EachGroup("values", *triples, []string{"num: ", "pow: ", "mod: "}, func(values []string) error {
+		// convert to int
+		var nums [3]int
+		for i, value := range values {
+			v, err := strconv.Atoi(value)
+			if err != nil || v < 1 {
+				return cli.Invalidf("%q is not a positive integer", value)
+			}
+			nums[i] = v
+		}
+		num, pow, mod := nums[0], nums[1], nums[2]
+
+		real_result := int(math.Pow(float64(num), float64(pow)))
+		self_check := fast_exp(num, pow) == real_result && fast_exp_mod(num, pow, mod) == real_result%mod
+
+		if out.JSON() {
+			return out.Record(result{
+				Num:        num,
+				Pow:        pow,
+				Mod:        mod,
+				FastExp:    fast_exp(num, pow),
+				FastExpMod: fast_exp_mod(num, pow, mod),
+				SelfCheck:  self_check,
+			})
+		}
+		fmt.Printf("fast_exp: %d\n", fast_exp(num, pow))
+		fmt.Printf("fast_exp_mod: %d\n", fast_exp_mod(num, pow, mod))
+		fmt.Printf("self check: %t\n", self_check)
+		return nil
+	}))
+}
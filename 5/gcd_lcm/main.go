@@ -0,0 +1,81 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"strconv"
+
+	"example.com/m/v2/cli"
+)
+
+func gcd(a, b int) int {
+	if a == 0 {
+		return b
+	}
+	if b == 0 {
+		return a
+	}
+
+	if a == b {
+		return a
+	}
+
+	// ensure both arguments are non-negative
+	if a < 0 {
+		a *= -1
+	}
+	if b < 0 {
+		b *= -1
+	}
+
+	var A, B int
+	if a > b {
+		A = a
+		B = b
+	} else {
+		A = b
+		B = a
+	}
+
+	// now we have A>B
+	R := A % B
+
+	return gcd(B, R)
+}
+
+func lcm(a, b int) int {
+	return (a / gcd(a, b)) * b
+}
+
+// result is what the program reports for each pair in JSON mode.
+type result struct {
+	A   int `json:"a"`
+	B   int `json:"b"`
+	GCD int `json:"gcd"`
+	LCM int `json:"lcm"`
+}
+
+func main() {
+	pairs := flag.String("pairs", "", `comma-separated pairs of positive integers such as "12 18,7 5" (read from stdin if not given)`)
+	format := flag.String("format", "text", "output format: text or json")
+	flag.Parse()
+	out, err := cli.NewOutput(os.Stdout, *format)
+	cli.Check(err)
+
+	cli.Check(cli.Stdin().EachGroup("pairs", *pairs, []string{"A: ", "B: "}, func(values []string) error {
+		// convert to int
+		a, err_a := strconv.Atoi(values[0])
+		b, err_b := strconv.Atoi(values[1])
+		if err_a != nil || err_b != nil || a < 1 || b < 1 {
+			return cli.Invalidf("%q and %q are not both positive integers", values[0], values[1])
+		}
+
+		if out.JSON() {
+			return out.Record(result{A: a, B: b, GCD: gcd(a, b), LCM: lcm(a, b)})
+		}
+		fmt.Printf("GCD: %d\n", gcd(a, b))
+		fmt.Printf("LCM: %d\n", lcm(a, b))
+		return nil
+	}))
+}
@@ -1,9 +1,13 @@
 package main
 
 import (
+	"flag"
 	"fmt"
-	"math/rand"
-	"time"
+	"os"
+	"strconv"
+
+	"example.com/m/v2/cli"
+	"example.com/m/v2/datagen"
 )
 
 func gcd(a, b int) int {
@@ -45,8 +49,8 @@ func lcm(a, b int) int {
 	return (a / gcd(a, b)) * b
 }
 
-// Initialize a pseudorandom number generator.
-var random = rand.New(rand.NewSource(time.Now().UnixNano())) // Initialize with a changing seed
+// The pseudorandom number generator, which main seeds.
+var random *datagen.Generator
 
 // Return a pseudo random number in the range [min, max).
 func rand_range(min int, max int) int {
@@ -129,8 +133,33 @@ func find_prime(min, max int) int {
 	}
 }
 
+// key is what the program reports about the key pair in JSON mode.
+type key struct {
+	Seed            int64 `json:"seed"`
+	Modulus         int   `json:"modulus"`
+	PublicExponent  int   `json:"public_exponent"`
+	P               int   `json:"p"`
+	Q               int   `json:"q"`
+	Lambda          int   `json:"lambda"`
+	PrivateExponent int   `json:"private_exponent"`
+}
+
+// result is what the program reports for each message in JSON mode.
+type result struct {
+	Message    int `json:"message"`
+	Ciphertext int `json:"ciphertext"`
+	Plaintext  int `json:"plaintext"`
+}
+
 func main() {
-	rand.Seed(time.Now().UnixNano())
+	messages := flag.String("messages", "", "comma-separated messages to encrypt (read from stdin if not given)")
+	seed := flag.Int64("seed", 0, "random seed for the key (default: the current time)")
+	format := flag.String("format", "text", "output format: text or json")
+	flag.Parse()
+	out, err := cli.NewOutput(os.Stdout, *format)
+	cli.Check(err)
+
+	random = cli.Generator(*seed)
 
 	p := find_prime(10000, 50000)
 	q := find_prime(10000, 50000)
@@ -145,31 +174,37 @@ func main() {
 
 	d := inverse_mod(e, lambda_n)
 
-	fmt.Println("*** Public ***")
-	fmt.Printf("Public key modulus:    %d\n", n)
-	fmt.Printf("Public key exponent e: %d\n", e)
-
-	fmt.Println("*** Private ***")
-	fmt.Printf("Primes:    %d, %d\n", p, q)
-	fmt.Printf("λ(n):      %d\n", lambda_n)
-	fmt.Printf("d:         %d\n", d)
-	fmt.Println()
-
-	for {
-		var m int
-		fmt.Printf("Message:    ")
-		fmt.Scan(&m)
+	if out.JSON() {
+		cli.Check(out.Record(key{Seed: random.Seed(), Modulus: n, PublicExponent: e, P: p, Q: q, Lambda: lambda_n, PrivateExponent: d}))
+	} else {
+		fmt.Printf("Seed: %d\n", random.Seed())
+		fmt.Println("*** Public ***")
+		fmt.Printf("Public key modulus:    %d\n", n)
+		fmt.Printf("Public key exponent e: %d\n", e)
+
+		fmt.Println("*** Private ***")
+		fmt.Printf("Primes:    %d, %d\n", p, q)
+		fmt.Printf("λ(n):      %d\n", lambda_n)
+		fmt.Printf("d:         %d\n", d)
+		fmt.Println()
+	}
 
-		if m < 1 {
-			break
+	cli.Check(cli.Stdin().EachString("messages", *messages, "Message:    ", func(m_string string) error {
+		// The message must be smaller than the modulus to survive the round trip.
+		m, err := strconv.Atoi(m_string)
+		if err != nil || m < 1 || m >= n {
+			return cli.Invalidf("%q is not an integer between 1 and %d", m_string, n-1)
 		}
 
 		ciphertext := fast_exp_mod(m, e, n)
-		fmt.Printf("Ciphertext: %d\n", ciphertext)
-
 		plaintext := fast_exp_mod(ciphertext, d, n)
-		fmt.Printf("Plaintext:  %d\n", plaintext)
 
+		if out.JSON() {
+			return out.Record(result{Message: m, Ciphertext: ciphertext, Plaintext: plaintext})
+		}
+		fmt.Printf("Ciphertext: %d\n", ciphertext)
+		fmt.Printf("Plaintext:  %d\n", plaintext)
 		fmt.Println()
-	}
+		return nil
+	}))
 }
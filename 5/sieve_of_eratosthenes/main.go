@@ -0,0 +1,111 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"strconv"
+	"time"
+
+	"example.com/m/v2/cli"
+)
+
+// Build a sieve of Eratosthenes.
+func sieve_of_eratosthenes(max int) []bool {
+	result := make([]bool, max)
+	for i := range result {
+		result[i] = true
+	}
+
+	// 1 is not prime
+	result[0] = false
+
+	current_prime := 2
+	for {
+		// remove all the multiples of current_prime
+		for remove_num := current_prime * 2; remove_num <= max; remove_num += current_prime {
+			result[remove_num-1] = false
+		}
+
+		// search for the next prime
+		found_new := false
+		for potential_next := current_prime + 1; potential_next <= max; potential_next++ {
+			if result[potential_next-1] {
+				found_new = true
+				current_prime = potential_next
+				break
+			}
+		}
+
+		// if we have not found any new primes, we are done
+		if !found_new {
+			break
+		}
+	}
+
+	return result
+}
+
+func print_sieve(sieve []bool) {
+	for i, v := range sieve {
+		if v {
+			fmt.Printf("%d ", i+1)
+		}
+	}
+	fmt.Println()
+}
+
+func sieve_to_primes(sieve []bool) []int {
+	result := make([]int, 0)
+	for i, v := range sieve {
+		if v {
+			result = append(result, i+1)
+		}
+	}
+	return result
+}
+
+// Sieves up to this size are printed in full.
+const max_printed = 1000
+
+// result is what the program reports for each sieve in JSON mode.
+type result struct {
+	Sieve          string  `json:"sieve"`
+	Max            int     `json:"max"`
+	ElapsedSeconds float64 `json:"elapsed_seconds"`
+	Primes         []int   `json:"primes,omitempty"`
+}
+
+func main() {
+	max_list := flag.String("max", "", "comma-separated sieve sizes (read from stdin if not given)")
+	format := flag.String("format", "text", "output format: text or json")
+	flag.Parse()
+	out, err := cli.NewOutput(os.Stdout, *format)
+	cli.Check(err)
+
+	cli.Check(cli.Stdin().EachString("max", *max_list, "Max: ", func(max_string string) error {
+		max, err := strconv.Atoi(max_string)
+		if err != nil || max < 0 {
+			return cli.Invalidf("%q is not a non-negative integer", max_string)
+		}
+
+		start := time.Now()
+		sieve := sieve_of_eratosthenes(max)
+		elapsed := time.Since(start)
+
+		r := result{Sieve: "eratosthenes", Max: max, ElapsedSeconds: elapsed.Seconds()}
+		if max <= max_printed {
+			r.Primes = sieve_to_primes(sieve)
+		}
+		if out.JSON() {
+			return out.Record(r)
+		}
+
+		fmt.Printf("Elapsed: %f seconds\n", elapsed.Seconds())
+		if max <= max_printed {
+			print_sieve(sieve)
+			fmt.Println(r.Primes)
+		}
+		return nil
+	}))
+}
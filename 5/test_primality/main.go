@@ -1,14 +1,18 @@
 package main
 
 import (
+	"flag"
 	"fmt"
 	"math"
-	"math/rand"
-	"time"
+	"os"
+	"strconv"
+
+	"example.com/m/v2/cli"
+	"example.com/m/v2/datagen"
 )
 
-// Initialize a pseudorandom number generator.
-var random = rand.New(rand.NewSource(time.Now().UnixNano())) // Initialize with a changing seed
+// The pseudorandom number generator, which main seeds.
+var random *datagen.Generator
 
 // Return a pseudo random number in the range [min, max).
 func rand_range(min int, max int) int {
@@ -90,13 +94,35 @@ func test_known_values() {
 	}
 }
 
+// result is what the program reports for each number of digits in JSON mode.
+type result struct {
+	Seed   int64 `json:"seed"`
+	Digits int   `json:"digits"`
+	Prime  int   `json:"prime"`
+}
+
+// Primes with more digits than this don't fit in an int.
+const max_digits = 18
+
 func main() {
-	test_known_values()
+	digit_list := flag.String("digits", "", "comma-separated numbers of digits (read from stdin if not given)")
+	seed := flag.Int64("seed", 0, "random seed (default: the current time)")
+	format := flag.String("format", "text", "output format: text or json")
+	flag.Parse()
+	out, err := cli.NewOutput(os.Stdout, *format)
+	cli.Check(err)
+	random = cli.Generator(*seed)
+
+	if !out.JSON() {
+		fmt.Printf("Seed: %d\n", random.Seed())
+		test_known_values()
+	}
 
-	for {
-		var num_of_digits int
-		fmt.Printf(" # Digits: ")
-		fmt.Scan(&num_of_digits)
+	cli.Check(cli.Stdin().EachString("digits", *digit_list, " # Digits: ", func(digits_string string) error {
+		num_of_digits, err := strconv.Atoi(digits_string)
+		if err != nil || num_of_digits < 1 || num_of_digits > max_digits {
+			return cli.Invalidf("%q is not an integer between 1 and %d", digits_string, max_digits)
+		}
 
 		min := int(math.Pow10(num_of_digits - 1))
 		max := int(math.Pow10(num_of_digits))
@@ -106,6 +132,11 @@ func main() {
 			min = 2
 		}
 
-		fmt.Printf(" Prime: %d\n\n", find_prime(min, max))
-	}
+		prime := find_prime(min, max)
+		if out.JSON() {
+			return out.Record(result{Seed: random.Seed(), Digits: num_of_digits, Prime: prime})
+		}
+		fmt.Printf(" Prime: %d\n\n", prime)
+		return nil
+	}))
 }
@@ -1,9 +1,12 @@
 package main
 
 import (
+	"flag"
 	"fmt"
-	"math/rand"
 	"time"
+
+	"example.com/m/v2/datagen"
+	"example.com/m/v2/stats"
 )
 
 const num_items = 20
@@ -21,17 +24,10 @@ type Item struct {
 }
 
 // Make some random items.
-func make_items(num_items, min_value, max_value, min_weight, max_weight int) []Item {
-	// Initialize a pseudorandom number generator.
-	//random := rand.New(rand.NewSource(time.Now().UnixNano())) // Initialize with a changing seed
-	random := rand.New(rand.NewSource(1337)) // Initialize with a fixed seed
-
+func make_items(g *datagen.Generator, num_items, min_value, max_value, min_weight, max_weight int) []Item {
 	items := make([]Item, num_items)
-	for i := 0; i < num_items; i++ {
-		items[i] = Item{
-			random.Intn(max_value-min_value+1) + min_value,
-			random.Intn(max_weight-min_weight+1) + min_weight,
-			false}
+	for i, item := range g.Items(num_items, min_value, max_value, min_weight, max_weight) {
+		items[i] = Item{item.Value, item.Weight, false}
 	}
 	return items
 }
@@ -96,70 +92,74 @@ func print_selected(items []Item) {
 	fmt.Println()
 }
 
-func run_algorithm(alg func([]Item, int) ([]Item, int, int), items []Item, allowed_weight int) {
+func run_algorithm(name string, alg func([]Item, int, *stats.Stats) ([]Item, int), items []Item, allowed_weight int) {
 	// Copy the items so the run isn't influenced by a previous run.
 	test_items := copy_items(items)
 
 	start := time.Now()
 
 	// Run the algorithm.
-	solution, total_value, function_calls := alg(test_items, allowed_weight)
+	s := &stats.Stats{}
+	solution, total_value := alg(test_items, allowed_weight, s)
 
 	elapsed := time.Since(start)
 
 	fmt.Printf("Elapsed: %f\n", elapsed.Seconds())
 	print_selected(solution)
-	fmt.Printf("Value: %d, Weight: %d, Calls: %d\n",
-		total_value, sum_weights(solution, false), function_calls)
+	fmt.Printf("Value: %d, Weight: %d\n", total_value, sum_weights(solution, false))
+	fmt.Println(stats.MakeReport(name, len(items), s))
 	fmt.Println()
 }
 
 // Recursively assign values in or out of the solution.
-// Return the best assignment, value of that assignment,
-// and the number of function calls we made.
-func exhaustive_search(items []Item, allowed_weight int) ([]Item, int, int) {
-	return do_exhaustive_search(items, allowed_weight, 0)
+// Return the best assignment and the value of that assignment.
+// The recursive calls are counted in s.
+func exhaustive_search(items []Item, allowed_weight int, s *stats.Stats) ([]Item, int) {
+	return do_exhaustive_search(items, allowed_weight, 0, s)
 }
 
-func do_exhaustive_search(items []Item, allowed_weight, next_index int) ([]Item, int, int) {
+func do_exhaustive_search(items []Item, allowed_weight, next_index int, s *stats.Stats) ([]Item, int) {
+	s.Call(next_index + 1)
+
 	// base case - the 'next' index is one which does not exist
 	if next_index == len(items) {
 		// in this case, we already have a fully built solution from our previous function calls, so return it
 		solution := copy_items(items)
 		total_value := solution_value(items, allowed_weight)
-		return solution, total_value, 1
+		return solution, total_value
 	}
 
 	// otherwise, we need to find the best solution from each of the two branches
 
 	// try including this item
 	items[next_index].is_selected = true
-	incl_solution, incl_value, incl_calls_count := do_exhaustive_search(items, allowed_weight, next_index+1)
+	incl_solution, incl_value := do_exhaustive_search(items, allowed_weight, next_index+1, s)
 
 	// try excluding this item
 	items[next_index].is_selected = false
-	excl_solution, excl_value, excl_calls_count := do_exhaustive_search(items, allowed_weight, next_index+1)
+	excl_solution, excl_value := do_exhaustive_search(items, allowed_weight, next_index+1, s)
 
-	total_calls := incl_calls_count + excl_calls_count + 1
 	if incl_value >= excl_value {
 		// including is better
-		return incl_solution, incl_value, total_calls
+		return incl_solution, incl_value
 	} else {
 		// excluding is better
-		return excl_solution, excl_value, total_calls
+		return excl_solution, excl_value
 	}
 }
 
-func branch_and_bound(items []Item, allowed_weight int) ([]Item, int, int) {
+func branch_and_bound(items []Item, allowed_weight int, s *stats.Stats) ([]Item, int) {
 	best_value := 0
 	current_value := 0
 	current_weight := 0
 	remaining_value := sum_values(items, true)
 
-	return do_branch_and_bound(items, allowed_weight, 0, best_value, current_value, current_weight, remaining_value)
+	return do_branch_and_bound(items, allowed_weight, 0, best_value, current_value, current_weight, remaining_value, s)
 }
 
-func do_branch_and_bound(items []Item, allowed_weight, next_index, best_value, current_value, current_weight, remaining_value int) ([]Item, int, int) {
+func do_branch_and_bound(items []Item, allowed_weight, next_index, best_value, current_value, current_weight, remaining_value int, s *stats.Stats) ([]Item, int) {
+	s.Call(next_index + 1)
+
 	// base case - the 'next' index is one which does not exist
 	// this is a full assignment
 	if next_index == len(items) {
@@ -171,20 +171,20 @@ func do_branch_and_bound(items []Item, allowed_weight, next_index, best_value, c
 			panic("this is not better than the previous best solution")
 		}
 
-		return solution, current_value, 1
+		return solution, current_value
 	}
 
 	// we cannot do any better than the previous best solution, even if we added all the remaining items
 	if current_value+remaining_value <= best_value {
-		return nil, 0, 1
+		return nil, 0
 	}
 
 	// we can try including this item
 	var incl_solution []Item
-	incl_solution, incl_value, incl_calls_count := nil, 0, 1
+	incl_value := 0
 	if current_weight+items[next_index].weight <= allowed_weight {
 		items[next_index].is_selected = true
-		incl_solution, incl_value, incl_calls_count = do_branch_and_bound(items, allowed_weight, next_index+1, best_value, current_value+items[next_index].value, current_weight+items[next_index].weight, remaining_value-items[next_index].value)
+		incl_solution, incl_value = do_branch_and_bound(items, allowed_weight, next_index+1, best_value, current_value+items[next_index].value, current_weight+items[next_index].weight, remaining_value-items[next_index].value, s)
 
 		// if this solution has obtained a better value than the previously-known best value, then update this value
 		if incl_value > best_value {
@@ -194,29 +194,32 @@ func do_branch_and_bound(items []Item, allowed_weight, next_index, best_value, c
 
 	// try excluding this item, only in the case that we have a shot at beating our current-best value
 	var excl_solution []Item
-	excl_solution, excl_value, excl_calls_count := nil, 0, 1
+	excl_value := 0
 	if current_value+remaining_value-items[next_index].value > best_value {
 		items[next_index].is_selected = false
-		excl_solution, excl_value, excl_calls_count = do_branch_and_bound(items, allowed_weight, next_index+1, best_value, current_value, current_weight, remaining_value-items[next_index].value)
+		excl_solution, excl_value = do_branch_and_bound(items, allowed_weight, next_index+1, best_value, current_value, current_weight, remaining_value-items[next_index].value, s)
 		// there is no need to update best_value because there are no more recursive function calls
 	}
 
-	total_calls := incl_calls_count + excl_calls_count + 1
 	if incl_value >= excl_value {
 		// including is better
-		return incl_solution, incl_value, total_calls
+		return incl_solution, incl_value
 	} else {
 		// excluding is better
-		return excl_solution, excl_value, total_calls
+		return excl_solution, excl_value
 	}
 }
 
 func main() {
-	items := make_items(num_items, min_value, max_value, min_weight, max_weight)
+	seed := flag.Int64("seed", 1337, "random seed for the items")
+	flag.Parse()
+
+	items := make_items(datagen.New(*seed), num_items, min_value, max_value, min_weight, max_weight)
 	allowed_weight = sum_weights(items, true) / 2
 
 	// Display basic parameters.
 	fmt.Println("*** Parameters ***")
+	fmt.Printf("Seed: %d\n", *seed)
 	fmt.Printf("# items: %d\n", num_items)
 	fmt.Printf("Total value: %d\n", sum_values(items, true))
 	fmt.Printf("Total weight: %d\n", sum_weights(items, true))
@@ -227,7 +230,7 @@ func main() {
 		fmt.Println("Too many items.")
 	} else {
 		fmt.Println("*** Search ***")
-		//run_algorithm(exhaustive_search, items, allowed_weight)
-		run_algorithm(branch_and_bound, items, allowed_weight)
+		//run_algorithm("exhaustive_search", exhaustive_search, items, allowed_weight)
+		run_algorithm("branch_and_bound", branch_and_bound, items, allowed_weight)
 	}
 }
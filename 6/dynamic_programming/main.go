@@ -1,10 +1,13 @@
 package main
 
 import (
+	"flag"
 	"fmt"
-	"math/rand"
 	"sort"
 	"time"
+
+	"example.com/m/v2/datagen"
+	"example.com/m/v2/stats"
 )
 
 const num_items = 300
@@ -24,23 +27,18 @@ type Item struct {
 }
 
 // Make some random items.
-func make_items(num_items, min_value, max_value, min_weight, max_weight int) []Item {
-	// Initialize a pseudorandom number generator.
-	//random := rand.New(rand.NewSource(time.Now().UnixNano())) // Initialize with a changing seed
-	random := rand.New(rand.NewSource(1337)) // Initialize with a fixed seed
-
+func make_items(g *datagen.Generator, num_items, min_value, max_value, min_weight, max_weight int) []Item {
 	items := make([]Item, num_items)
-	for i := 0; i < num_items; i++ {
+	for i, item := range g.Items(num_items, min_value, max_value, min_weight, max_weight) {
 		items[i] = Item{
-			value:       random.Intn(max_value-min_value+1) + min_value,
-			weight:      random.Intn(max_weight-min_weight+1) + min_weight,
+			value:       item.Value,
+			weight:      item.Weight,
 			is_selected: false,
-			id:          i,
+			id:          item.ID,
 			blocked_by:  -1,
 			i_block:     nil,
 		}
 	}
-
 	return items
 }
 
@@ -98,70 +96,74 @@ func print_selected(items []Item) {
 	fmt.Println()
 }
 
-func run_algorithm(alg func([]Item, int) ([]Item, int, int), items []Item, allowed_weight int) {
+func run_algorithm(name string, alg func([]Item, int, *stats.Stats) ([]Item, int), items []Item, allowed_weight int) {
 	// Copy the items so the run isn't influenced by a previous run.
 	test_items := copy_items(items)
 
 	start := time.Now()
 
 	// Run the algorithm.
-	solution, total_value, function_calls := alg(test_items, allowed_weight)
+	s := &stats.Stats{}
+	solution, total_value := alg(test_items, allowed_weight, s)
 
 	elapsed := time.Since(start)
 
 	fmt.Printf("Elapsed: %f\n", elapsed.Seconds())
 	print_selected(solution)
-	fmt.Printf("Value: %d, Weight: %d, Calls: %d\n",
-		total_value, sum_weights(solution, false), function_calls)
+	fmt.Printf("Value: %d, Weight: %d\n", total_value, sum_weights(solution, false))
+	fmt.Println(stats.MakeReport(name, len(items), s))
 	fmt.Println()
 }
 
 // Recursively assign values in or out of the solution.
-// Return the best assignment, value of that assignment,
-// and the number of function calls we made.
-func exhaustive_search(items []Item, allowed_weight int) ([]Item, int, int) {
-	return do_exhaustive_search(items, allowed_weight, 0)
+// Return the best assignment and the value of that assignment.
+// The recursive calls are counted in s.
+func exhaustive_search(items []Item, allowed_weight int, s *stats.Stats) ([]Item, int) {
+	return do_exhaustive_search(items, allowed_weight, 0, s)
 }
 
-func do_exhaustive_search(items []Item, allowed_weight, next_index int) ([]Item, int, int) {
+func do_exhaustive_search(items []Item, allowed_weight, next_index int, s *stats.Stats) ([]Item, int) {
+	s.Call(next_index + 1)
+
 	// base case - the 'next' index is one which does not exist
 	if next_index == len(items) {
 		// in this case, we already have a fully built solution from our previous function calls, so return it
 		solution := copy_items(items)
 		total_value := solution_value(items, allowed_weight)
-		return solution, total_value, 1
+		return solution, total_value
 	}
 
 	// otherwise, we need to find the best solution from each of the two branches
 
 	// try including this item
 	items[next_index].is_selected = true
-	incl_solution, incl_value, incl_calls_count := do_exhaustive_search(items, allowed_weight, next_index+1)
+	incl_solution, incl_value := do_exhaustive_search(items, allowed_weight, next_index+1, s)
 
 	// try excluding this item
 	items[next_index].is_selected = false
-	excl_solution, excl_value, excl_calls_count := do_exhaustive_search(items, allowed_weight, next_index+1)
+	excl_solution, excl_value := do_exhaustive_search(items, allowed_weight, next_index+1, s)
 
-	total_calls := incl_calls_count + excl_calls_count + 1
 	if incl_value >= excl_value {
 		// including is better
-		return incl_solution, incl_value, total_calls
+		return incl_solution, incl_value
 	} else {
 		// excluding is better
-		return excl_solution, excl_value, total_calls
+		return excl_solution, excl_value
 	}
 }
 
-func branch_and_bound(items []Item, allowed_weight int) ([]Item, int, int) {
+func branch_and_bound(items []Item, allowed_weight int, s *stats.Stats) ([]Item, int) {
 	best_value := 0
 	current_value := 0
 	current_weight := 0
 	remaining_value := sum_values(items, true)
 
-	return do_branch_and_bound(items, allowed_weight, 0, best_value, current_value, current_weight, remaining_value)
+	return do_branch_and_bound(items, allowed_weight, 0, best_value, current_value, current_weight, remaining_value, s)
 }
 
-func do_branch_and_bound(items []Item, allowed_weight, next_index, best_value, current_value, current_weight, remaining_value int) ([]Item, int, int) {
+func do_branch_and_bound(items []Item, allowed_weight, next_index, best_value, current_value, current_weight, remaining_value int, s *stats.Stats) ([]Item, int) {
+	s.Call(next_index + 1)
+
 	// base case - the 'next' index is one which does not exist
 	// this is a full assignment
 	if next_index == len(items) {
@@ -173,20 +175,20 @@ func do_branch_and_bound(items []Item, allowed_weight, next_index, best_value, c
 			panic("this is not better than the previous best solution")
 		}
 
-		return solution, current_value, 1
+		return solution, current_value
 	}
 
 	// we cannot do any better than the previous best solution, even if we added all the remaining items
 	if current_value+remaining_value <= best_value {
-		return nil, 0, 1
+		return nil, 0
 	}
 
 	// we can try including this item
 	var incl_solution []Item
-	incl_solution, incl_value, incl_calls_count := nil, 0, 1
+	incl_value := 0
 	if current_weight+items[next_index].weight <= allowed_weight {
 		items[next_index].is_selected = true
-		incl_solution, incl_value, incl_calls_count = do_branch_and_bound(items, allowed_weight, next_index+1, best_value, current_value+items[next_index].value, current_weight+items[next_index].weight, remaining_value-items[next_index].value)
+		incl_solution, incl_value = do_branch_and_bound(items, allowed_weight, next_index+1, best_value, current_value+items[next_index].value, current_weight+items[next_index].weight, remaining_value-items[next_index].value, s)
 
 		// if this solution has obtained a better value than the previously-known best value, then update this value
 		if incl_value > best_value {
@@ -196,20 +198,19 @@ func do_branch_and_bound(items []Item, allowed_weight, next_index, best_value, c
 
 	// try excluding this item, only in the case that we have a shot at beating our current-best value
 	var excl_solution []Item
-	excl_solution, excl_value, excl_calls_count := nil, 0, 1
+	excl_value := 0
 	if current_value+remaining_value-items[next_index].value > best_value {
 		items[next_index].is_selected = false
-		excl_solution, excl_value, excl_calls_count = do_branch_and_bound(items, allowed_weight, next_index+1, best_value, current_value, current_weight, remaining_value-items[next_index].value)
+		excl_solution, excl_value = do_branch_and_bound(items, allowed_weight, next_index+1, best_value, current_value, current_weight, remaining_value-items[next_index].value, s)
 		// there is no need to update best_value because there are no more recursive function calls
 	}
 
-	total_calls := incl_calls_count + excl_calls_count + 1
 	if incl_value >= excl_value {
 		// including is better
-		return incl_solution, incl_value, total_calls
+		return incl_solution, incl_value
 	} else {
 		// excluding is better
-		return excl_solution, excl_value, total_calls
+		return excl_solution, excl_value
 	}
 }
 
@@ -250,7 +251,7 @@ func unblock_items(source Item, items []Item) {
 	}
 }
 
-func rods_technique(items []Item, allowed_weight int) ([]Item, int, int) {
+func rods_technique(items []Item, allowed_weight int, s *stats.Stats) ([]Item, int) {
 	best_value := 0
 	current_value := 0
 	current_weight := 0
@@ -258,7 +259,7 @@ func rods_technique(items []Item, allowed_weight int) ([]Item, int, int) {
 
 	make_block_lists(items)
 
-	return do_rods_technique(items, allowed_weight, 0, best_value, current_value, current_weight, remaining_value)
+	return do_rods_technique(items, allowed_weight, 0, best_value, current_value, current_weight, remaining_value, s)
 }
 
 type byBlockListLength []Item
@@ -273,7 +274,7 @@ func (s byBlockListLength) Less(i, j int) bool {
 	return len(s[i].i_block) > len(s[j].i_block)
 }
 
-func rods_technique_sorted(items []Item, allowed_weight int) ([]Item, int, int) {
+func rods_technique_sorted(items []Item, allowed_weight int, s *stats.Stats) ([]Item, int) {
 	best_value := 0
 	current_value := 0
 	current_weight := 0
@@ -289,10 +290,12 @@ func rods_technique_sorted(items []Item, allowed_weight int) ([]Item, int, int)
 
 	make_block_lists(items)
 
-	return do_rods_technique(items, allowed_weight, 0, best_value, current_value, current_weight, remaining_value)
+	return do_rods_technique(items, allowed_weight, 0, best_value, current_value, current_weight, remaining_value, s)
 }
 
-func do_rods_technique(items []Item, allowed_weight, next_index, best_value, current_value, current_weight, remaining_value int) ([]Item, int, int) {
+func do_rods_technique(items []Item, allowed_weight, next_index, best_value, current_value, current_weight, remaining_value int, s *stats.Stats) ([]Item, int) {
+	s.Call(next_index + 1)
+
 	// base case - the 'next' index is one which does not exist
 	// this is a full assignment
 	if next_index == len(items) {
@@ -304,20 +307,20 @@ func do_rods_technique(items []Item, allowed_weight, next_index, best_value, cur
 			panic("this is not better than the previous best solution")
 		}
 
-		return solution, current_value, 1
+		return solution, current_value
 	}
 
 	// we cannot do any better than the previous best solution, even if we added all the remaining items
 	if current_value+remaining_value <= best_value {
-		return nil, 0, 1
+		return nil, 0
 	}
 
 	// we can try including this item (only if not blocked)
 	var incl_solution []Item
-	incl_solution, incl_value, incl_calls_count := nil, 0, 1
+	incl_value := 0
 	if items[next_index].blocked_by == -1 && current_weight+items[next_index].weight <= allowed_weight {
 		items[next_index].is_selected = true
-		incl_solution, incl_value, incl_calls_count = do_rods_technique(items, allowed_weight, next_index+1, best_value, current_value+items[next_index].value, current_weight+items[next_index].weight, remaining_value-items[next_index].value)
+		incl_solution, incl_value = do_rods_technique(items, allowed_weight, next_index+1, best_value, current_value+items[next_index].value, current_weight+items[next_index].weight, remaining_value-items[next_index].value, s)
 
 		// if this solution has obtained a better value than the previously-known best value, then update this value
 		if incl_value > best_value {
@@ -327,31 +330,33 @@ func do_rods_technique(items []Item, allowed_weight, next_index, best_value, cur
 
 	// try excluding this item, only in the case that we have a shot at beating our current-best value
 	var excl_solution []Item
-	excl_solution, excl_value, excl_calls_count := nil, 0, 1
+	excl_value := 0
 	if current_value+remaining_value-items[next_index].value > best_value {
 		block_items(items[next_index], items)
 
 		items[next_index].is_selected = false
-		excl_solution, excl_value, excl_calls_count = do_rods_technique(items, allowed_weight, next_index+1, best_value, current_value, current_weight, remaining_value-items[next_index].value)
+		excl_solution, excl_value = do_rods_technique(items, allowed_weight, next_index+1, best_value, current_value, current_weight, remaining_value-items[next_index].value, s)
 		// there is no need to update best_value because there are no more recursive function calls
 
 		unblock_items(items[next_index], items)
 	}
 
-	total_calls := incl_calls_count + excl_calls_count + 1
 	if incl_value >= excl_value {
 		// including is better
-		return incl_solution, incl_value, total_calls
+		return incl_solution, incl_value
 	} else {
 		// excluding is better
-		return excl_solution, excl_value, total_calls
+		return excl_solution, excl_value
 	}
 }
 
 // Use dynamic programming to find a solution.
-// Return the best assignment, value of that assignment,
-// and the number of function calls we made.
-func dynamic_programming(items []Item, allowed_weight int) ([]Item, int, int) {
+// Return the best assignment and the value of that assignment.
+// The recursive calls are counted in s.
+func dynamic_programming(items []Item, allowed_weight int, s *stats.Stats) ([]Item, int) {
+	s.Call(1)
+	s.Alloc(2 * len(items) * (allowed_weight + 1))
+
 	// value[i][w] will hold the value of the best solution if the knapsack is only allowed to hold weight w and we are only allowed to use the items with indices 0 through i
 
 	value := make([][]int, len(items))
@@ -431,15 +436,19 @@ func dynamic_programming(items []Item, allowed_weight int) ([]Item, int, int) {
 		items[v].is_selected = true
 	}
 
-	return items, value[len(items)-1][allowed_weight], 1
+	return items, value[len(items)-1][allowed_weight]
 }
 
 func main() {
-	items := make_items(num_items, min_value, max_value, min_weight, max_weight)
+	seed := flag.Int64("seed", 1337, "random seed for the items")
+	flag.Parse()
+
+	items := make_items(datagen.New(*seed), num_items, min_value, max_value, min_weight, max_weight)
 	allowed_weight = sum_weights(items, true) / 2
 
 	// Display basic parameters.
 	fmt.Println("*** Parameters ***")
+	fmt.Printf("Seed: %d\n", *seed)
 	fmt.Printf("# items: %d\n", num_items)
 	fmt.Printf("Total value: %d\n", sum_values(items, true))
 	fmt.Printf("Total weight: %d\n", sum_weights(items, true))
@@ -448,5 +457,5 @@ func main() {
 
 	// Dynamic programming
 	fmt.Println("*** Dynamic programming ***")
-	run_algorithm(dynamic_programming, items, allowed_weight)
+	run_algorithm("dynamic_programming", dynamic_programming, items, allowed_weight)
 }
@@ -3,9 +3,12 @@
 package main
 
 import (
+	"flag"
 	"fmt"
-	"math/rand"
 	"time"
+
+	"example.com/m/v2/datagen"
+	"example.com/m/v2/stats"
 )
 
 const num_items = 20 // A reasonable value for exhaustive search.
@@ -23,17 +26,10 @@ type Item struct {
 }
 
 // Make some random items.
-func make_items(num_items, min_value, max_value, min_weight, max_weight int) []Item {
-	// Initialize a pseudorandom number generator.
-	//random := rand.New(rand.NewSource(time.Now().UnixNano())) // Initialize with a changing seed
-	random := rand.New(rand.NewSource(1337)) // Initialize with a fixed seed
-
+func make_items(g *datagen.Generator, num_items, min_value, max_value, min_weight, max_weight int) []Item {
 	items := make([]Item, num_items)
-	for i := 0; i < num_items; i++ {
-		items[i] = Item{
-			random.Intn(max_value-min_value+1) + min_value,
-			random.Intn(max_weight-min_weight+1) + min_weight,
-			false}
+	for i, item := range g.Items(num_items, min_value, max_value, min_weight, max_weight) {
+		items[i] = Item{item.Value, item.Weight, false}
 	}
 	return items
 }
@@ -98,66 +94,72 @@ func print_selected(items []Item) {
 	fmt.Println()
 }
 
-func run_algorithm(alg func([]Item, int) ([]Item, int, int), items []Item, allowed_weight int) {
+func run_algorithm(name string, alg func([]Item, int, *stats.Stats) ([]Item, int), items []Item, allowed_weight int) {
 	// Copy the items so the run isn't influenced by a previous run.
 	test_items := copy_items(items)
 
 	start := time.Now()
 
 	// Run the algorithm.
-	solution, total_value, function_calls := alg(test_items, allowed_weight)
+	s := &stats.Stats{}
+	solution, total_value := alg(test_items, allowed_weight, s)
 
 	elapsed := time.Since(start)
 
 	fmt.Printf("Elapsed: %f\n", elapsed.Seconds())
 	print_selected(solution)
-	fmt.Printf("Value: %d, Weight: %d, Calls: %d\n",
-		total_value, sum_weights(solution, false), function_calls)
+	fmt.Printf("Value: %d, Weight: %d\n", total_value, sum_weights(solution, false))
+	fmt.Println(stats.MakeReport(name, len(items), s))
 	fmt.Println()
 }
 
 // Recursively assign values in or out of the solution.
-// Return the best assignment, value of that assignment,
-// and the number of function calls we made.
-func exhaustive_search(items []Item, allowed_weight int) ([]Item, int, int) {
-	return do_exhaustive_search(items, allowed_weight, 0)
+// Return the best assignment and the value of that assignment.
+// The recursive calls are counted in s.
+func exhaustive_search(items []Item, allowed_weight int, s *stats.Stats) ([]Item, int) {
+	return do_exhaustive_search(items, allowed_weight, 0, s)
 }
 
-func do_exhaustive_search(items []Item, allowed_weight, next_index int) ([]Item, int, int) {
+func do_exhaustive_search(items []Item, allowed_weight, next_index int, s *stats.Stats) ([]Item, int) {
+	s.Call(next_index + 1)
+
 	// base case - the 'next' index is one which does not exist
 	if next_index == len(items) {
 		// in this case, we already have a fully built solution from our previous function calls, so return it
 		solution := copy_items(items)
 		total_value := solution_value(items, allowed_weight)
-		return solution, total_value, 1
+		return solution, total_value
 	}
 
 	// otherwise, we need to find the best solution from each of the two branches
 
 	// try including this item
 	items[next_index].is_selected = true
-	incl_solution, incl_value, incl_calls_count := do_exhaustive_search(items, allowed_weight, next_index+1)
+	incl_solution, incl_value := do_exhaustive_search(items, allowed_weight, next_index+1, s)
 
 	// try excluding this item
 	items[next_index].is_selected = false
-	excl_solution, excl_value, excl_calls_count := do_exhaustive_search(items, allowed_weight, next_index+1)
+	excl_solution, excl_value := do_exhaustive_search(items, allowed_weight, next_index+1, s)
 
-	total_calls := incl_calls_count + excl_calls_count + 1
 	if incl_value >= excl_value {
 		// including is better
-		return incl_solution, incl_value, total_calls
+		return incl_solution, incl_value
 	} else {
 		// excluding is better
-		return excl_solution, excl_value, total_calls
+		return excl_solution, excl_value
 	}
 }
 
 func main() {
-	items := make_items(num_items, min_value, max_value, min_weight, max_weight)
+	seed := flag.Int64("seed", 1337, "random seed for the items")
+	flag.Parse()
+
+	items := make_items(datagen.New(*seed), num_items, min_value, max_value, min_weight, max_weight)
 	allowed_weight = sum_weights(items, true) / 2
 
 	// Display basic parameters.
 	fmt.Println("*** Parameters ***")
+	fmt.Printf("Seed: %d\n", *seed)
 	fmt.Printf("# items: %d\n", num_items)
 	fmt.Printf("Total value: %d\n", sum_values(items, true))
 	fmt.Printf("Total weight: %d\n", sum_weights(items, true))
@@ -166,9 +168,9 @@ func main() {
 
 	// Exhaustive search
 	if num_items > 25 { // Only run exhaustive search if num_items <= 25.
-		fmt.Println("Too many items for exhaustive search\n")
+		fmt.Printf("Too many items for exhaustive search\n\n")
 	} else {
 		fmt.Println("*** Exhaustive Search ***")
-		run_algorithm(exhaustive_search, items, allowed_weight)
+		run_algorithm("exhaustive_search", exhaustive_search, items, allowed_weight)
 	}
 }
@@ -0,0 +1,7 @@
+module example.com/m
+
+go 1.23
+
+require example.com/m/v2 v2.0.0
+
+replace example.com/m/v2 => ./1